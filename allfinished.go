@@ -0,0 +1,42 @@
+package multibar
+
+// OnAllFinished registers fn to be called when the last unfinished bar finishes or fails, e.g.
+// to print a summary or send a desktop notification exactly when the bars show everything done.
+// fn runs in the goroutine that finished the bar, after its OnFinish callbacks. It runs again
+// when bars created later all finish too. Aggregates, gauges, histograms and queues are not waited for.
+func (m *MultiBar) OnAllFinished(fn func()) {
+	m.mu.Lock()
+	m.onAllFinished = append(m.onAllFinished, fn)
+	m.mu.Unlock()
+}
+
+// barRestarted lets OnAllFinished callbacks run again once the reset bar finishes.
+func (m *MultiBar) barRestarted() {
+	m.mu.Lock()
+	m.allFinished = false
+	m.mu.Unlock()
+}
+
+// barFinished runs the OnAllFinished callbacks if the bar that just finished was the last one.
+func (m *MultiBar) barFinished() {
+	m.mu.Lock()
+	if m.allFinished || len(m.onAllFinished) == 0 {
+		m.mu.Unlock()
+		return
+	}
+	for _, b := range m.bars {
+		b.mu.Lock()
+		done := !b.workLocked() || b.finished
+		b.mu.Unlock()
+		if !done {
+			m.mu.Unlock()
+			return
+		}
+	}
+	m.allFinished = true
+	hooks := m.onAllFinished
+	m.mu.Unlock()
+	for _, fn := range hooks {
+		fn()
+	}
+}
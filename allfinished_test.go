@@ -0,0 +1,18 @@
+package multibar
+
+import "testing"
+
+func TestOnAllFinishedIgnoresHistogramAndQueue(t *testing.T) {
+	mb := New(WithDisabled(true))
+	defer mb.Stop()
+	mb.NewHistogram("latency", []float64{1, 10}).Observe(3)
+	mb.NewQueue("queue", 2).Enqueue(0)
+	bar := mb.NewBar(2, "work")
+	calls := 0
+	mb.OnAllFinished(func() { calls++ })
+
+	bar.Add(2)
+	if calls != 1 {
+		t.Fatalf("OnAllFinished called %d times, want 1", calls)
+	}
+}
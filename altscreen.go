@@ -0,0 +1,10 @@
+package multibar
+
+// WithAltScreen draws the bars on the alternate screen of the terminal, like less or vim,
+// and switches back on Stop, leaving the scrollback untouched. Suits dashboards with many bars.
+// Ignored when the output is not a terminal.
+func WithAltScreen() Option {
+	return func(m *MultiBar) {
+		m.altScreen = true
+	}
+}
@@ -0,0 +1,137 @@
+package multibar
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// SortOrder controls the order in which bars are displayed.
+type SortOrder int
+
+const (
+	SortCreation     SortOrder = iota // creation order (default)
+	SortActiveFirst                   // running bars first, finished ones last
+	SortAlphabetical                  // by description
+)
+
+var sortOrderNames = map[SortOrder]string{
+	SortCreation:     "creation",
+	SortActiveFirst:  "active",
+	SortAlphabetical: "alpha",
+}
+
+func (o SortOrder) String() string {
+	if name, ok := sortOrderNames[o]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseSortOrder parses the names returned by SortOrder.String.
+func ParseSortOrder(name string) (SortOrder, bool) {
+	for o, n := range sortOrderNames {
+		if n == name {
+			return o, true
+		}
+	}
+	return SortCreation, false
+}
+
+// WithSort sets the display order of bars, e.g. SortActiveFirst to keep running bars
+// from being buried between finished ones.
+func WithSort(order SortOrder) Option {
+	return func(m *MultiBar) {
+		m.sortOrder = order
+	}
+}
+
+// SetSort changes the display order of bars.
+func (m *MultiBar) SetSort(order SortOrder) {
+	m.mu.Lock()
+	m.sortOrder = order
+	m.mu.Unlock()
+	m.render(true)
+}
+
+// SetFilter shows only bars whose description contains substr, ignoring case. Empty substr shows all bars.
+func (m *MultiBar) SetFilter(substr string) {
+	m.mu.Lock()
+	m.filter = substr
+	m.mu.Unlock()
+	m.render(true)
+}
+
+// arrangeBars applies visibility rules, the description filter and the sort order.
+func arrangeBars(bars []*Bar, visible func(BarSnapshot) bool, filter string, order SortOrder) []*Bar {
+	if visible == nil && filter == "" && order == SortCreation {
+		return bars
+	}
+	type entry struct {
+		bar  *Bar
+		snap BarSnapshot
+	}
+	filter = strings.ToLower(filter)
+	entries := make([]entry, 0, len(bars))
+	for _, bar := range bars {
+		snap := bar.Snapshot()
+		if visible != nil && !visible(snap) {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(snap.Description), filter) {
+			continue
+		}
+		entries = append(entries, entry{bar, snap})
+	}
+	switch order {
+	case SortActiveFirst:
+		slices.SortStableFunc(entries, func(a, b entry) int {
+			return cmp.Compare(boolRank(a.snap.Finished), boolRank(b.snap.Finished))
+		})
+	case SortAlphabetical:
+		slices.SortStableFunc(entries, func(a, b entry) int {
+			return strings.Compare(a.snap.Description, b.snap.Description)
+		})
+	}
+	shown := make([]*Bar, len(entries))
+	for i, e := range entries {
+		shown[i] = e.bar
+	}
+	return shown
+}
+
+func boolRank(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// WithRemoveFinished removes bars from the display once they finish, so short-lived bars
+// don't fill the screen. They still count in aggregates and overall progress.
+func WithRemoveFinished() Option {
+	return func(m *MultiBar) {
+		m.removeFinished = true
+	}
+}
+
+// BarRemoveOnFinish removes the bar from the display once it finishes, see WithRemoveFinished.
+func BarRemoveOnFinish() BarOption {
+	return func(b *Bar) {
+		b.removeOnFinish = true
+	}
+}
+
+// dropFinished leaves out finished bars that are removed on finish.
+func dropFinished(bars []*Bar, all bool) []*Bar {
+	shown := bars[:0:0]
+	for _, b := range bars {
+		b.mu.Lock()
+		removed := b.finished && !b.failed && (all || b.removeOnFinish)
+		b.mu.Unlock()
+		if !removed {
+			shown = append(shown, b)
+		}
+	}
+	return shown
+}
@@ -0,0 +1,141 @@
+package multibar
+
+import (
+	"errors"
+	"io"
+	"net"
+)
+
+const (
+	cursorHome  = "\033[H"
+	clearScreen = "\033[2J"
+)
+
+// viewer is a read-only client attached through ListenUnix.
+type viewer struct {
+	conn   net.Conn
+	frames chan []byte
+}
+
+// ListenUnix lets other terminals attach to the live bars with cmd/mbattach.
+// Each attached client receives the frames drawn at the top of its screen.
+// Closing the returned io.Closer stops listening and detaches all clients.
+func (m *MultiBar) ListenUnix(path string) (io.Closer, error) {
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	if m.viewers == nil {
+		m.viewers = make(map[*viewer]struct{})
+	}
+	m.mu.Unlock()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					m.detachAll()
+					return
+				}
+				continue
+			}
+			m.attach(conn)
+		}
+	}()
+	return ln, nil
+}
+
+func (m *MultiBar) attach(conn net.Conn) {
+	v := &viewer{conn: conn, frames: make(chan []byte, 1)}
+	m.mu.Lock()
+	if m.stopped {
+		// Only the final frame is shown
+		close(v.frames)
+	} else {
+		m.viewers[v] = struct{}{}
+	}
+	last := m.lastBody
+	m.mu.Unlock()
+
+	go func() {
+		defer m.detach(v)
+		if _, err := io.WriteString(conn, clearScreen+cursorHome); err != nil {
+			return
+		}
+		if last != nil {
+			if _, err := conn.Write(last); err != nil {
+				return
+			}
+		}
+		for frame := range v.frames {
+			if _, err := conn.Write(frame); err != nil {
+				return
+			}
+		}
+	}()
+}
+
+func (m *MultiBar) detach(v *viewer) {
+	m.mu.Lock()
+	if _, ok := m.viewers[v]; ok {
+		delete(m.viewers, v)
+		close(v.frames)
+	}
+	m.mu.Unlock()
+	v.conn.Close()
+}
+
+func (m *MultiBar) detachAll() {
+	m.mu.Lock()
+	viewers := make([]*viewer, 0, len(m.viewers))
+	for v := range m.viewers {
+		viewers = append(viewers, v)
+	}
+	m.mu.Unlock()
+	for _, v := range viewers {
+		m.detach(v)
+	}
+}
+
+// releaseViewers lets attached viewers finish writing their pending frames and disconnect.
+func (m *MultiBar) releaseViewers() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for v := range m.viewers {
+		delete(m.viewers, v)
+		close(v.frames)
+	}
+}
+
+// broadcast sends the frame body to attached viewers. Slow viewers skip frames instead of blocking rendering.
+func (m *MultiBar) broadcast(body []byte) {
+	m.mu.Lock()
+	listening := m.viewers != nil
+	m.mu.Unlock()
+	if !listening {
+		return
+	}
+	frame := make([]byte, 0, len(cursorHome)+len(body))
+	frame = append(frame, cursorHome...)
+	frame = append(frame, body...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastBody = frame
+	for v := range m.viewers {
+		select {
+		case v.frames <- frame:
+		default:
+			// Replace the pending frame with the latest one
+			select {
+			case <-v.frames:
+			default:
+			}
+			select {
+			case v.frames <- frame:
+			default:
+			}
+		}
+	}
+}
@@ -2,178 +2,510 @@ package multibar
 
 import (
 	"fmt"
-	"io"
+	"math"
 	"strings"
 	"sync"
 	"time"
-	"unicode/utf8"
 )
 
-var (
-	partialBlocks = []rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉', '█'}
-	spinners      = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-)
+var partialBlocks = []rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉', '█'}
+
+// barWidth is the width of the progress bar column.
+const barWidth = 30
 
 type Bar struct {
 	mb                   multiBarInterface
+	id                   int
 	value, max           int64
+	lower                int64 // value of an empty bar, see Gauge
+	gauge                bool  // value rises and falls, not counted as work
+	widget               bool  // shows measurements, not work, see widgetOptions
 	startedAt, updatedAt time.Time
 	description          string
+	status               string              // secondary line, see SetStatus
+	suffix               string              // annotation after the time columns, see SetSuffix
+	detail               func(b *Bar) string // extra line below the bar, see BarDetail
+	path                 []string            // hierarchical description, see SetPath
+	spinner              Spinner
+	state                BarState
+	role                 Role
+	color                Style // overrides Theme.Bar and Theme.Label
+	unit                 Unit
+	rates                rateTracker
+	etaMode              ETAMode
+	estimator            Estimator
+	newEstimator         func() Estimator // nil when set with SetEstimator
+	stallTimeout         time.Duration
+	hidden               Column
+	tags                 []string
+	derive               func() (value, max int64, finished bool) // state source of aggregate bars
+	children             []childBar                               // bars counted by a parent, see NewParent
+	draw                 func(width int) string                   // replaces the progress bar column, e.g. Queue
+	stallNotified        bool
 	finished             bool
+	finishedAtMax        bool          // finished by reaching max, undone when the value drops, see rollbackLocked
+	stopped              bool          // the MultiBar is stopped, updates are ignored
+	pausedAt             time.Time     // zero unless paused, see Pause
+	pausedFor            time.Duration // total of past pauses
+	lazyStart            bool          // the clock starts on the first update, see WithLazyStart
+	overflow             OverflowPolicy
+	extended             bool          // max was raised by OverflowExtend
+	longETA              time.Duration // last estimate above the long ETA threshold, see WithLongETA
+	longETAAt            time.Time     // when longETA was estimated, zero when not long
+	aborted              bool          // unfinished when the context of the MultiBar was done, see WithContext
+	failed               bool          // finished with an error, see Fail
+	err                  error         // reason of the failure, may be nil
+	removeOnFinish       bool
+	onFinish             []func(b *Bar, elapsed time.Duration) // see OnFinish
+	version              uint64                                // bumped by every change, see redraw
+	cache                *segmentCache
+	label                *renderedLabel // see paddedLabel
+	middleware           []UpdateMiddleware
+	chain                UpdateFunc // middleware around Add and SetValue, see Use
+	lastEventAt          time.Time
+	eventPending         bool // a progress event was held back by the throttle, see flushProgressEvents
 	mu                   sync.Mutex
 }
 
 type multiBarInterface interface {
 	updateMaxLabelLength(description string)
 	render(force ...bool)
+	wake()
+	barFinished()
+	barRestarted()
+	emit(kind EventKind, s BarSnapshot)
+	pathSeparator() string
+	remove(b *Bar)
+	misuse(err error, description string)
+	barLine(b *Bar) string
 }
 
-func (b *Bar) Reset() {
+// Reset restarts the bar from zero: timers, rates and the ETA start over, and a finished or
+// failed bar runs again, so one bar can be reused for task after task. opts change the max
+// and description for the new run, see ResetMax and ResetDescription. OnFinish callbacks
+// stay registered and fire again.
+func (b *Bar) Reset(opts ...ResetOption) {
 	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	description := b.description
+	for _, opt := range opts {
+		opt(b)
+	}
+	renamed := b.description != description
+	now := time.Now()
 	b.value = 0
-	b.startedAt = time.Now()
-	b.updatedAt = b.startedAt
+	b.finished, b.finishedAtMax, b.failed, b.err = false, false, false, nil
+	b.extended = false
+	b.stallNotified = false
+	b.startLocked(now)
+	if b.lazyStart {
+		b.startedAt = time.Time{}
+	}
+	b.lastEventAt, b.eventPending = now, false
+	snap := b.snapshotLocked(now)
 	b.mu.Unlock()
-	b.mb.render()
+	b.mb.barRestarted()
+	if renamed {
+		b.mb.emit(EventDescription, snap)
+		b.mb.updateMaxLabelLength(snap.Description)
+	}
+	b.mb.emit(EventProgress, snap)
+	b.redraw()
 }
 
 func (b *Bar) SetDescription(description string) {
+	b.mu.Lock()
+	b.path = nil
+	b.mu.Unlock()
+	b.setDescription(description)
+}
+
+func (b *Bar) setDescription(description string) {
 	b.mu.Lock()
 	b.description = description
+	snap := b.snapshotLocked(time.Now())
 	b.mu.Unlock()
+	b.mb.emit(EventDescription, snap)
 	b.mb.updateMaxLabelLength(description)
-	b.mb.render()
+	b.redraw()
+}
+
+// SetSpinner overrides the spinner of this bar. Nil restores the MultiBar default.
+func (b *Bar) SetSpinner(s Spinner) {
+	b.mu.Lock()
+	b.spinner = s
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// SetRole sets the emphasis of the bar, see Theme.Primary, Theme.Secondary and Theme.Detail.
+func (b *Bar) SetRole(r Role) {
+	b.mu.Lock()
+	b.role = r
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// SetColor overrides the fill of the running bar and the label with the style, e.g. SGR(34).
+// Finished and error states keep their theme colors. Empty style restores the theme.
+func (b *Bar) SetColor(color Style) {
+	b.mu.Lock()
+	b.color = color
+	b.mu.Unlock()
+	b.redraw()
 }
 
 func (b *Bar) SetValue(value int64) {
+	if chain := b.updateChain(); chain != nil {
+		chain(Update{Bar: b, Kind: UpdateSet, Value: value})
+		return
+	}
+	b.setValue(value)
+}
+
+func (b *Bar) setValue(value int64) {
+	now := time.Now()
 	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	if b.startedAt.IsZero() {
+		b.startLocked(now)
+	}
+	wasFinished, old, description := b.finished, b.value, b.description
 	b.value = value
-	b.updatedAt = time.Now()
+	overflowed := b.overflowLocked()
+	rolledBack := b.rollbackLocked()
+	misused := wasFinished && !rolledBack && b.value != old
+	if b.reachedMaxLocked() {
+		b.finished, b.finishedAtMax = true, true
+	}
+	b.updatedAt = now
+	b.observeLocked(now)
+	kind, snap, ok := b.updateEventLocked(now, wasFinished && !rolledBack)
 	b.mu.Unlock()
-	b.mb.render()
+	if misused {
+		b.mb.misuse(ErrAddAfterFinish, description)
+	}
+	if rolledBack {
+		b.mb.barRestarted()
+	}
+	if overflowed {
+		b.mb.misuse(ErrValueAboveMax, description)
+	}
+	if ok {
+		b.mb.emit(kind, snap)
+	}
+	if kind == EventFinished {
+		b.redraw(true)
+		b.runFinishHooks()
+		return
+	}
+	b.redraw()
 }
 
+// SetMax changes the total of the bar. A bar created with Undefined max, e.g. a download
+// started before its size is known, switches from the indeterminate marker to a percentage
+// bar with an ETA, keeping its elapsed time. A bar whose value already reached max finishes.
 func (b *Bar) SetMax(max int64) {
+	now := time.Now()
 	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	misused, description := max != Undefined && max < b.value, b.description
+	wasFinished := b.finished
 	b.max = max
+	b.extended = false
+	b.overflowLocked() // lowering max below the value is already misuse
+	if b.reachedMaxLocked() {
+		b.finished, b.finishedAtMax = true, true
+		b.updatedAt = now
+	}
+	b.estimator.ObserveProgress(b.activeLocked(now), b.value, b.max)
+	kind, snap, ok := b.updateEventLocked(now, wasFinished)
 	b.mu.Unlock()
-	b.mb.render()
+	if misused {
+		b.mb.misuse(ErrMaxBelowValue, description)
+	}
+	if ok {
+		b.mb.emit(kind, snap)
+	}
+	if kind == EventFinished {
+		b.redraw(true)
+		b.runFinishHooks()
+		return
+	}
+	b.redraw()
 }
 
 func (b *Bar) Add(n int64) {
+	if chain := b.updateChain(); chain != nil {
+		chain(Update{Bar: b, Kind: UpdateAdd, Value: n})
+		return
+	}
+	b.add(n)
+}
+
+func (b *Bar) add(n int64) {
+	now := time.Now()
 	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	if b.startedAt.IsZero() {
+		b.startLocked(now)
+	}
+	wasFinished, old, description := b.finished, b.value, b.description
 	b.value += n
-	b.finished = b.value == b.max && b.max != Undefined
-	b.updatedAt = time.Now()
+	overflowed := b.overflowLocked()
+	rolledBack := b.rollbackLocked()
+	// A clamped value may not change at all
+	misused := wasFinished && !rolledBack && b.value != old
+	if b.reachedMaxLocked() {
+		b.finished, b.finishedAtMax = true, true
+	}
+	b.updatedAt = now
+	b.observeLocked(now)
+	kind, snap, ok := b.updateEventLocked(now, wasFinished && !rolledBack)
 	b.mu.Unlock()
-	b.mb.render()
+	if misused {
+		b.mb.misuse(ErrAddAfterFinish, description)
+	}
+	if rolledBack {
+		b.mb.barRestarted()
+	}
+	if overflowed {
+		b.mb.misuse(ErrValueAboveMax, description)
+	}
+	if ok {
+		b.mb.emit(kind, snap)
+	}
+	if kind == EventFinished {
+		// Make sure the finished state reaches the screen
+		b.redraw(true)
+		b.runFinishHooks()
+		return
+	}
+	b.redraw()
+}
+
+// reachedMaxLocked reports whether an unfinished bar finishes because its value reached max.
+// Gauges never finish, nor do bars whose max was extended by OverflowExtend.
+func (b *Bar) reachedMaxLocked() bool {
+	return !b.finished && !b.gauge && !b.extended && b.max != Undefined && b.value == b.max
+}
+
+// rollbackLocked unfinishes a bar that finished by reaching max when its value drops below max
+// again, e.g. when a retry rolls back its progress. Bars finished with Finish or Fail stay finished.
+func (b *Bar) rollbackLocked() bool {
+	if !b.finishedAtMax || b.value >= b.max {
+		return false
+	}
+	b.finished, b.finishedAtMax = false, false
+	return true
 }
 
 func (b *Bar) Finish() {
 	b.mu.Lock()
-	if b.finished {
+	// An explicit Finish keeps a bar that reached max finished
+	b.finishedAtMax = false
+	if b.finished || b.stopped {
 		b.mu.Unlock()
 		return
 	}
 	b.updatedAt = time.Now()
 	b.finished = true
+	b.lastEventAt = b.updatedAt
+	snap := b.snapshotLocked(b.updatedAt)
 	b.mu.Unlock()
-	b.mb.render(true)
+	b.mb.emit(EventFinished, snap)
+	b.redraw(true)
+	b.runFinishHooks()
 }
 
-func (b *Bar) render(w io.Writer, spinner string, maxLabelLength int) {
+// Remove takes the bar off the display, e.g. when its task is cancelled.
+// The bar stops counting in aggregates and overall progress; further updates are not shown.
+func (b *Bar) Remove() {
+	b.mb.remove(b)
+}
+
+// segments renders the parts of the bar line. elide is the number of leading path parts to blank.
+func (b *Bar) segments(f *frame, elide int) Segments {
+	theme := f.theme
 	b.mu.Lock()
 	isError := b.max != Undefined && b.value > b.max
+	aborted := b.aborted
+	failed := b.failed
+	startedAt := b.startedAt
 	description := b.description
 	value := b.value
 	maxVal := b.max
 	finished := b.finished
-	startedAt := b.startedAt
-	updatedAt := b.updatedAt
+	now := time.Now()
+	elapsed := b.elapsedLocked(now)
+	var rateStr string
+	if f.rate || b.unit == UnitBytes {
+		rateStr = b.rateColumnLocked(now, f.rateModes)
+	}
+	remaining, hasETA, longETA := b.remainingLocked(now, f.longETA)
+	spinnerFrames := b.spinner
+	state := b.displayStateLocked()
+	pausedAt := b.pausedAt
+	emph := theme.emphasis(b.role)
+	barStyle, labelStyle := theme.Bar, theme.Label
+	customColor := b.color != ""
+	if customColor {
+		barStyle, labelStyle = b.color, b.color
+	}
+	unit := b.unit
+	etaMode := b.etaMode
+	idle, stalled := b.stalledLocked(now)
+	hidden := b.hidden
+	suffix := b.suffix
+	if failed && b.err != nil {
+		suffix = b.err.Error()
+	}
+	draw := b.draw
+	lower := b.lower
+	if elide > 0 {
+		description = elidePath(b.path, elide, f.pathSeparator)
+	}
 	b.mu.Unlock()
+	if etaMode == 0 {
+		etaMode = f.etaMode
+	}
+	if len(spinnerFrames) == 0 {
+		spinnerFrames = f.spinner
+	}
 
-	// Calculate percentage - fixed width 4 characters
-	var percentStr string
-	if finished && maxVal != Undefined {
-		percentStr = "100%"
-	} else if maxVal != Undefined {
-		percent := int((value * 100) / maxVal)
-		percentStr = fmt.Sprintf("%3d%%", percent) // Fixed width: 3 digits + %
-	} else {
-		percentStr = "    " // Empty space for undefined progress (4 spaces)
+	formatDuration := f.formatDuration
+	if longETA && !finished {
+		formatDuration = FormatCompact
 	}
 
-	// Calculate times
-	var elapsed time.Duration
-	if finished {
-		if !updatedAt.IsZero() {
-			elapsed = updatedAt.Sub(startedAt)
-		} else {
-			elapsed = time.Since(startedAt)
-		}
-	} else {
-		elapsed = time.Since(startedAt)
+	counterValue, counterMax := value, maxVal
+	if lower != 0 && maxVal != Undefined {
+		// Gauges fill and show percent relative to their range, the counter shows actual values
+		value, maxVal = value-lower, maxVal-lower
 	}
-	var estimatedStr string
-	if finished {
-		estimatedStr = "       "
-	} else if maxVal != Undefined && value > 0 {
-		// Estimated total time = elapsed * max / value
-		estimated := time.Duration(float64(elapsed) * float64(maxVal) / float64(value))
-		estimatedStr = formatDuration(estimated)
-	} else {
-		estimatedStr = "       " // 7 spaces for H:MM:SS placeholder
+
+	// A failed bar keeps showing how far it got
+	completed := finished && !failed
+	percentStr := formatPercent(value, maxVal, completed, f.percentDecimals)
+
+	var startedStr string
+	if f.startTime != "" && !startedAt.IsZero() {
+		startedStr = startedAt.Format(f.startTime)
 	}
 
-	// Ensure minimal width (7 characters like "0:00:00")
-	if len(estimatedStr) < 7 && maxVal > 0 {
-		estimatedStr = " " + estimatedStr
+	var counterStr string
+	if f.counter || unit == UnitBytes {
+		counterStr = formatCounter(counterValue, counterMax, unit)
+	}
+
+	// ETA stays empty for finished bars and until it can be estimated; alignSegments pads it
+	var estimatedStr string
+	switch {
+	case failed:
+		estimatedStr = "failed"
+	case finished:
+	case aborted:
+		estimatedStr = "aborted"
+	case !pausedAt.IsZero():
+		estimatedStr = "paused " + formatDuration(now.Sub(pausedAt))
+	case stalled:
+		estimatedStr = "stalled " + formatDuration(idle)
+	case hasETA:
+		estimated := elapsed + remaining
+		if etaMode == ETARemaining {
+			estimated = remaining
+		}
+		estimatedStr = formatDuration(estimated)
 	}
 
 	// Build progress bar
-	barWidth := 30 // Width of the progress bar
-	barStr := b.buildProgressBar(value, maxVal, barWidth, finished, isError)
+	var barStr string
+	if draw != nil {
+		barStr = draw(barWidth)
+	} else {
+		barStr = b.buildProgressBar(value, maxVal, barWidth, completed)
+	}
+	switch {
+	case failed:
+		barStr = theme.BarError.Render(barStr)
+	case finished:
+		barStr = theme.BarFinished.Render(barStr)
+	case isError || aborted:
+		barStr = theme.BarError.Render(barStr)
+	default:
+		// An explicit bar color wins over the theme gradient
+		if painted, ok := paintGradient(barStr, value, maxVal, barWidth, theme); ok && !customColor {
+			barStr = painted
+		} else {
+			barStr = barStyle.Render(barStr)
+		}
+	}
+	if emph != "" {
+		barStr = string(emph) + barStr + colorReset
+	}
 
 	// Format output with proper alignment based on max label length
 	// Build fixed-width label area (description only), spinner printed separately
+	spinner := spinnerFrames.frameAt(f.spinnerIndex)
 	if finished {
-		spinner = " "
+		spinner = spinnerFrames.blank()
 	}
 
-	descLen := utf8.RuneCountInString(description)
-	pad := maxLabelLength - descLen
-	if pad < 0 {
-		pad = 0
+	if f.marquee && !finished && displayWidth(description) > f.maxLabelLength {
+		description = marqueeLabel(description, f.maxLabelLength, now)
 	}
-	labelOut := description + strings.Repeat(" ", pad)
+	labelOut := b.paddedLabel(description, f.maxLabelLength, emph+labelStyle)
 
-	// Print line: spinner, space, label, bar, percent, elapsed, estimated
 	var spinnerOut string
 	switch {
+	case state != StateRunning && !finished:
+		glyph, style := theme.stateGlyph(state, spinnerFrames)
+		spinnerOut = (emph + style).Render(glyph)
+	case failed || aborted:
+		spinnerOut = (emph + theme.BarError).Render(failedGlyph + spinnerFrames.blank()[1:])
 	case isError:
-		spinnerOut = colorRed + spinner + colorReset
+		spinnerOut = (emph + theme.BarError).Render(spinner)
 	case finished:
-		spinnerOut = colorGreen + spinner + colorReset
+		spinnerOut = (emph + theme.BarFinished).Render(spinner)
+	case stalled:
+		spinnerOut = (emph + theme.Stalled).Render(spinner)
 	default:
-		spinnerOut = spinner
+		spinnerOut = (emph + theme.Spinner).Render(spinner)
 	}
 
-	fmt.Fprintf(w, "%s %s %s %s %s %s",
-		spinnerOut, // spinner (or space)
-		labelOut,   // fixed-width description
-		barStr,     // bar
-		colorMagenta+percentStr+colorReset,
-		colorYellow+formatDuration(elapsed)+colorReset,
-		colorCyan+estimatedStr+colorReset,
-	)
+	s := Segments{
+		Spinner: spinnerOut, // spinner (or space)
+		Desc:    labelOut,   // fixed-width description
+		Bar:     barStr,
+		Percent: (emph + theme.Percent).Render(percentStr),
+		Counter: (emph + theme.Counter).Render(counterStr),
+		Rate:    (emph + theme.Rate).Render(rateStr),
+		Started: (emph + theme.Started).Render(startedStr),
+		Elapsed: (emph + theme.Elapsed).Render(formatDuration(elapsed)),
+		ETA:     (emph + theme.ETA).Render(estimatedStr),
+		Suffix:  (emph + theme.Suffix).Render(suffix),
+	}
+	s.hide(f.hidden, hidden&^f.hidden)
+	return s
 }
 
-func (b *Bar) buildProgressBar(value, maxVal int64, width int, isFinished bool, isError bool) string {
+func (b *Bar) buildProgressBar(value, maxVal int64, width int, isFinished bool) string {
 	if maxVal == Undefined {
 		if isFinished {
 			// Finished undefined: full green bar
-			barStr := strings.Repeat(string(partialBlocks[8]), width)
-			return colorGreen + barStr + colorReset
+			return strings.Repeat(string(partialBlocks[8]), width)
 		}
 		// Indeterminate progress: tri-symbol marker advances by 1 gradation per unit
 		totalUnits := width * 8
@@ -211,49 +543,14 @@ func (b *Bar) buildProgressBar(value, maxVal int64, width int, isFinished bool,
 
 	// Calculate filled portion in terms of total units (width * 8) using integer math
 	totalUnits := width * 8
-	filledUnits := int((value * int64(totalUnits)) / maxVal)
-
-	var barStr string
 	if isFinished {
-		// Completed bar - green
-		barStr = strings.Repeat(string(partialBlocks[8]), width)
-		return colorGreen + barStr + colorReset
-	} else {
-		// Working bar - default terminal color
-		filledStr := ""
-		emptyStr := ""
-
-		// Calculate how many characters are fully filled and the remainder
-		fullChars := filledUnits / 8
-		remainder := filledUnits % 8
-
-		if fullChars >= width {
-			fullChars = width
-			remainder = 0
-		}
-
-		// Full filled characters
-		filledStr = strings.Repeat(string(partialBlocks[8]), fullChars)
-
-		// Partial character only if there is room
-		extra := 0
-		if remainder > 0 && fullChars < width {
-			filledStr += string(partialBlocks[remainder])
-			extra = 1
-		}
-
-		// Empty characters
-		emptyChars := width - fullChars - extra
-		if emptyChars < 0 {
-			emptyChars = 0
-		}
-		emptyStr = strings.Repeat(string(partialBlocks[0]), emptyChars)
-
-		if isError {
-			return colorRed + filledStr + emptyStr + colorReset
-		}
-		return filledStr + emptyStr
+		return fillTable(width)[totalUnits]
+	}
+	filledUnits := 0
+	if maxVal > 0 {
+		filledUnits = int(min(max((value*int64(totalUnits))/maxVal, 0), int64(totalUnits)))
 	}
+	return fillTable(width)[filledUnits]
 }
 
 func (b *Bar) Value() int64 {
@@ -270,10 +567,29 @@ func (b *Bar) Max() int64 {
 	return m
 }
 
-func formatDuration(d time.Duration) string {
-	totalSeconds := int64(d.Seconds())
-	hours := totalSeconds / 3600
-	minutes := (totalSeconds % 3600) / 60
-	seconds := totalSeconds % 60
-	return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+// formatPercent formats progress with the given decimals at a fixed width: "  7%", " 42.5%", "100.00%".
+// Progress is rounded down so 100% means finished; undefined progress is blank.
+func formatPercent(value, maxVal int64, finished bool, decimals int) string {
+	width := 4
+	if decimals > 0 {
+		width += decimals + 1
+	}
+	switch {
+	case maxVal == Undefined:
+		return strings.Repeat(" ", width)
+	case finished:
+		return fmt.Sprintf("%*.*f%%", width-1, decimals, 100.0)
+	case maxVal == 0:
+		return fmt.Sprintf("%*.*f%%", width-1, decimals, 0.0)
+	}
+	scale := math.Pow(10, float64(decimals))
+	percent := math.Floor(float64(value)*100*scale/float64(maxVal)) / scale
+	return fmt.Sprintf("%*.*f%%", width-1, decimals, percent)
+}
+
+func formatCounter(value, maxVal int64, unit Unit) string {
+	if maxVal == Undefined {
+		return formatValue(value, unit)
+	}
+	return formatValue(value, unit) + "/" + formatValue(maxVal, unit)
 }
@@ -0,0 +1,70 @@
+package multibar
+
+// BarOption configures a bar when it is created, see NewBar64 and RegisterTemplate.
+type BarOption func(*Bar)
+
+// BarSpinner sets the spinner of the bar.
+func BarSpinner(s Spinner) BarOption {
+	return func(b *Bar) {
+		b.spinner = s
+	}
+}
+
+// BarRole sets the emphasis of the bar.
+func BarRole(r Role) BarOption {
+	return func(b *Bar) {
+		b.role = r
+	}
+}
+
+// BarColor sets the fill and label style of the bar, see (*Bar).SetColor.
+func BarColor(color Style) BarOption {
+	return func(b *Bar) {
+		b.color = color
+	}
+}
+
+// BarUnit sets how values of the bar are displayed.
+func BarUnit(u Unit) BarOption {
+	return func(b *Bar) {
+		b.unit = u
+	}
+}
+
+// BarETAMode sets the ETA column mode of the bar.
+func BarETAMode(mode ETAMode) BarOption {
+	return func(b *Bar) {
+		b.etaMode = mode
+	}
+}
+
+// BarEstimator sets the factory of the ETA estimator of the bar.
+func BarEstimator(newEstimator func() Estimator) BarOption {
+	return func(b *Bar) {
+		if newEstimator != nil {
+			b.newEstimator = newEstimator
+		}
+	}
+}
+
+// RegisterTemplate stores bar options under name, so bars of the same kind look alike across an application.
+// Registering an existing name replaces it.
+func (m *MultiBar) RegisterTemplate(name string, opts ...BarOption) {
+	m.mu.Lock()
+	if m.templates == nil {
+		m.templates = make(map[string][]BarOption)
+	}
+	m.templates[name] = opts
+	m.mu.Unlock()
+}
+
+// NewBarFromTemplate creates a bar with the options registered under name.
+// Extra options are applied after the template ones. An unknown name creates a plain bar.
+func (m *MultiBar) NewBarFromTemplate(name string, maxValue int64, description string, opts ...BarOption) *Bar {
+	m.mu.Lock()
+	tmpl := m.templates[name]
+	m.mu.Unlock()
+	all := make([]BarOption, 0, len(tmpl)+len(opts))
+	all = append(append(all, tmpl...), opts...)
+	return m.NewBar64(maxValue, description, all...)
+}
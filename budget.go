@@ -0,0 +1,30 @@
+package multibar
+
+import "time"
+
+// maxRenderInterval caps how far WithCPUBudget may slow down rendering.
+const maxRenderInterval = time.Second
+
+// WithCPUBudget limits rendering to about fraction of one core, e.g. 0.02 for 2%.
+// The time spent rendering is measured and the interval between frames grows when
+// rendering gets expensive, up to a second. Forced redraws, like finishing a bar, are not delayed.
+func WithCPUBudget(fraction float64) Option {
+	return func(m *MultiBar) {
+		m.cpuBudget = fraction
+	}
+}
+
+// adaptRenderInterval updates the average render cost and the resulting minimal interval between frames.
+func (m *MultiBar) adaptRenderInterval(cost time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cpuBudget <= 0 {
+		return
+	}
+	if m.renderCost == 0 {
+		m.renderCost = cost
+	} else {
+		m.renderCost = (m.renderCost*7 + cost) / 8
+	}
+	m.renderInterval = min(time.Duration(float64(m.renderCost)/m.cpuBudget), maxRenderInterval)
+}
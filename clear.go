@@ -0,0 +1,22 @@
+package multibar
+
+// WithClearOnFinish erases the bars from the screen on Stop instead of leaving the final frame,
+// so only the output of the program remains.
+func WithClearOnFinish() Option {
+	return func(m *MultiBar) {
+		m.clearOnFinish = true
+	}
+}
+
+// Clear erases the bars from the screen. While running, the next update draws them again;
+// after Stop they stay erased.
+func (m *MultiBar) Clear() {
+	m.renderMu.Lock()
+	defer m.renderMu.Unlock()
+	m.mu.Lock()
+	drawn := !m.plain && !m.disabled && !m.forwarding && !m.headless
+	m.mu.Unlock()
+	if drawn {
+		m.out.clear()
+	}
+}
@@ -0,0 +1,41 @@
+// Command mbattach shows the live bars of a process that called (*multibar.MultiBar).ListenUnix.
+//
+//	mbattach /tmp/myjob.sock
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+)
+
+const (
+	cursorOff = "\033[?25l"
+	cursorOn  = "\033[?25h"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: mbattach <socket>")
+		os.Exit(2)
+	}
+	conn, err := net.Dial("unix", os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// Restore the cursor when interrupted
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		<-sig
+		conn.Close()
+	}()
+
+	fmt.Print(cursorOff)
+	io.Copy(os.Stdout, conn)
+	fmt.Print(cursorOn)
+}
@@ -0,0 +1,21 @@
+// Command multibar helps triaging multibar output problems.
+//
+//	multibar doctor
+//
+// prints what was detected about the terminal and which render profile will be used.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/metalim/multibar"
+)
+
+func main() {
+	if len(os.Args) != 2 || os.Args[1] != "doctor" {
+		fmt.Fprintln(os.Stderr, "usage: multibar doctor")
+		os.Exit(2)
+	}
+	multibar.Diagnose(os.Stdout)
+}
@@ -0,0 +1,75 @@
+package multibar
+
+import "strings"
+
+// Column identifies a segment of a bar line. Columns combine as a bit set.
+type Column uint
+
+const (
+	ColumnSpinner Column = 1 << iota
+	ColumnLabel
+	ColumnBar
+	ColumnPercent
+	ColumnCounter
+	ColumnRate
+	ColumnElapsed
+	ColumnETA
+	ColumnSuffix
+	ColumnStarted
+)
+
+// WithHiddenColumns removes columns from every line, e.g. ColumnElapsed|ColumnETA.
+func WithHiddenColumns(cols Column) Option {
+	return func(m *MultiBar) {
+		m.hidden = cols
+	}
+}
+
+// BarHiddenColumns hides columns of a single bar. They are left blank so other bars stay aligned.
+func BarHiddenColumns(cols Column) BarOption {
+	return func(b *Bar) {
+		b.hidden = cols
+	}
+}
+
+// SetHiddenColumns hides columns of this bar, see BarHiddenColumns.
+func (b *Bar) SetHiddenColumns(cols Column) {
+	b.mu.Lock()
+	b.hidden = cols
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// fields maps columns to their segments.
+func (s *Segments) fields() []struct {
+	col Column
+	ptr *string
+} {
+	return []struct {
+		col Column
+		ptr *string
+	}{
+		{ColumnSpinner, &s.Spinner},
+		{ColumnLabel, &s.Desc},
+		{ColumnBar, &s.Bar},
+		{ColumnPercent, &s.Percent},
+		{ColumnCounter, &s.Counter},
+		{ColumnRate, &s.Rate},
+		{ColumnStarted, &s.Started},
+		{ColumnElapsed, &s.Elapsed},
+		{ColumnETA, &s.ETA},
+		{ColumnSuffix, &s.Suffix},
+	}
+}
+
+// hide empties the removed columns and blanks the ones hidden for this bar only.
+func (s *Segments) hide(removed, blanked Column) {
+	for _, field := range s.fields() {
+		switch {
+		case removed&field.col != 0:
+			*field.ptr = ""
+		case blanked&field.col != 0:
+			*field.ptr = strings.Repeat(" ", visibleWidth(*field.ptr))
+		}
+	}
+}
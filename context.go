@@ -0,0 +1,55 @@
+package multibar
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// WithContext ties the MultiBar to ctx: once ctx is done, unfinished bars are marked aborted
+// and the MultiBar stops, restoring the cursor.
+func WithContext(ctx context.Context) Option {
+	return func(m *MultiBar) {
+		m.ctx = ctx
+	}
+}
+
+// StopOnContext stops the MultiBar as soon as ctx is done, restoring the cursor and flushing
+// the final frame. Calling Stop afterwards, e.g. deferred before shutdown logs are printed,
+// waits for the teardown to complete. The returned function cancels the association, like
+// the one of context.AfterFunc.
+func (m *MultiBar) StopOnContext(ctx context.Context) (stop func() bool) {
+	m.mu.Lock()
+	m.contextStop = true
+	m.mu.Unlock()
+	return context.AfterFunc(ctx, m.Stop)
+}
+
+// abort marks the unfinished bars as aborted and stops.
+func (m *MultiBar) abort() {
+	m.mu.Lock()
+	bars := slices.Clone(m.bars)
+	m.mu.Unlock()
+	now := time.Now()
+	for _, b := range bars {
+		b.markAborted(now)
+	}
+	m.Stop()
+}
+
+// markAborted marks the bar as aborted unless it is finished, see abort.
+func (b *Bar) markAborted(now time.Time) {
+	b.mu.Lock()
+	aborted := !b.finished && !b.stopped && !b.aborted && !b.gauge && b.derive == nil
+	var snap BarSnapshot
+	if aborted {
+		b.aborted = true
+		b.version++
+		snap = b.snapshotLocked(now)
+	}
+	b.mu.Unlock()
+	if aborted {
+		b.mb.emit(EventAborted, snap)
+		b.redraw()
+	}
+}
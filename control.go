@@ -0,0 +1,139 @@
+package multibar
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ControlState is the display state reported and changed by ControlHandler.
+type ControlState struct {
+	Paused bool     `json:"paused"`
+	Filter string   `json:"filter"`
+	Sort   string   `json:"sort"`
+	Rate   []string `json:"rate,omitempty"`
+}
+
+// ControlHandler exposes a small HTTP API to adjust the display of a running job:
+//
+//	GET  /state                 current ControlState as JSON
+//	POST /pause                 stop redrawing the terminal
+//	POST /resume                redraw again
+//	POST /filter?q=<substring>  show only matching bars, empty q shows all
+//	POST /sort?order=<name>     creation, active or alpha
+//	POST /rate?mode=<names>     comma-separated window, instant or avg; empty or default restores the default
+//
+// Every request answers with the resulting ControlState. POST requests a browser sends from
+// another origin are rejected, so web pages the user visits can't control the display.
+func (m *MultiBar) ControlHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /state", m.serveControlState)
+	mux.HandleFunc("POST /pause", func(w http.ResponseWriter, r *http.Request) {
+		m.setPaused(true)
+		m.serveControlState(w, r)
+	})
+	mux.HandleFunc("POST /resume", func(w http.ResponseWriter, r *http.Request) {
+		m.setPaused(false)
+		m.serveControlState(w, r)
+	})
+	mux.HandleFunc("POST /filter", func(w http.ResponseWriter, r *http.Request) {
+		m.SetFilter(r.FormValue("q"))
+		m.serveControlState(w, r)
+	})
+	mux.HandleFunc("POST /sort", func(w http.ResponseWriter, r *http.Request) {
+		order, ok := ParseSortOrder(r.FormValue("order"))
+		if !ok {
+			http.Error(w, "unknown sort order", http.StatusBadRequest)
+			return
+		}
+		m.SetSort(order)
+		m.serveControlState(w, r)
+	})
+	mux.HandleFunc("POST /rate", func(w http.ResponseWriter, r *http.Request) {
+		var modes []RateMode
+		// No modes restore the default rate column
+		if mode := r.FormValue("mode"); mode == "" || mode == "default" {
+			m.SetRateMode()
+			m.serveControlState(w, r)
+			return
+		}
+		for name := range strings.SplitSeq(r.FormValue("mode"), ",") {
+			mode, ok := ParseRateMode(strings.TrimSpace(name))
+			if !ok {
+				http.Error(w, "unknown rate mode", http.StatusBadRequest)
+				return
+			}
+			modes = append(modes, mode)
+		}
+		m.SetRateMode(modes...)
+		m.serveControlState(w, r)
+	})
+	return crossOriginGuard(mux)
+}
+
+// crossOriginGuard rejects state-changing requests from other origins (CSRF). Clients like
+// curl send neither Sec-Fetch-Site nor Origin and are allowed.
+func crossOriginGuard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && !sameOrigin(r) {
+			http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// sameOrigin reports whether the request comes from the page served on the same host, or
+// from outside a browser.
+func sameOrigin(r *http.Request) bool {
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "same-origin", "none":
+		return true
+	case "":
+		// Older browsers only send Origin
+	default:
+		return false
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+func (m *MultiBar) serveControlState(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	state := ControlState{Paused: m.paused, Filter: m.filter, Sort: m.sortOrder.String()}
+	for _, mode := range m.rateModes {
+		state.Rate = append(state.Rate, mode.String())
+	}
+	m.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+// Pause suspends drawing and erases the bars, e.g. to ask an interactive question mid-run
+// without the bars repainting over the prompt. Bars keep counting; Resume draws them again.
+func (m *MultiBar) Pause() {
+	m.setPaused(true)
+	m.Clear()
+	// The bars are gone when Pause returns
+	m.out.wait(m.out.lastQueued(), flushTimeout)
+}
+
+// Resume draws the bars again after Pause.
+func (m *MultiBar) Resume() {
+	m.setPaused(false)
+}
+
+// setPaused suspends or resumes drawing; the last frame stays on screen while paused.
+func (m *MultiBar) setPaused(paused bool) {
+	m.mu.Lock()
+	m.paused = paused
+	m.mu.Unlock()
+	if !paused {
+		m.render(true)
+	}
+}
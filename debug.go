@@ -0,0 +1,41 @@
+package multibar
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithDebugOverlay adds a line under the bars with the render time of the previous frame,
+// frames per second, bytes written per frame and frames skipped by throttling,
+// to help tuning refresh rates and diagnosing slow terminals.
+func WithDebugOverlay() Option {
+	return func(m *MultiBar) {
+		m.debug = &frameStats{}
+	}
+}
+
+// frameStats are the render statistics shown by WithDebugOverlay.
+type frameStats struct {
+	cost        time.Duration // render time of the previous frame
+	bytes       int           // bytes written by the previous frame
+	dropped     int           // render calls skipped by throttling
+	fps         float64
+	windowStart time.Time
+	windowCount int
+}
+
+// frameDone records a drawn frame.
+func (s *frameStats) frameDone(now time.Time, cost time.Duration, bytes int) {
+	s.cost, s.bytes = cost, bytes
+	s.windowCount++
+	if d := now.Sub(s.windowStart); d >= time.Second {
+		s.fps = float64(s.windowCount) / d.Seconds()
+		s.windowStart, s.windowCount = now, 0
+	}
+}
+
+// line formats the statistics, counting frames dropped for a slow writer as dropped too.
+func (s *frameStats) line(slowDropped int) string {
+	return fmt.Sprintf("debug: frame %v, %.1f fps, %s/frame, %d dropped",
+		s.cost.Round(10*time.Microsecond), s.fps, formatBytes(int64(s.bytes)), s.dropped+slowDropped)
+}
@@ -0,0 +1,116 @@
+package multibar
+
+import "strings"
+
+// Align controls how a decorator's segment is padded to the widest one in the frame.
+type Align int
+
+const (
+	AlignNone  Align = iota // as rendered
+	AlignLeft               // padded on the right
+	AlignRight              // padded on the left
+)
+
+// Decorator renders one segment of a bar line. The segments of all decorators are joined
+// with Theme.Separator; empty segments are left out.
+type Decorator struct {
+	Name   string // key of the segment in Segments.Extra, for layouts
+	Align  Align
+	Render func(c DecorContext) string
+}
+
+// DecorContext is what a decorator renders from.
+type DecorContext struct {
+	Bar      *Bar
+	Segments *Segments // built-in segments of the line, styled and aligned
+}
+
+// columnNames are the names of the built-in column decorators, matching the Segments fields.
+var columnNames = map[Column]string{
+	ColumnSpinner: "Spinner",
+	ColumnLabel:   "Desc",
+	ColumnBar:     "Bar",
+	ColumnPercent: "Percent",
+	ColumnCounter: "Counter",
+	ColumnRate:    "Rate",
+	ColumnElapsed: "Elapsed",
+	ColumnETA:     "ETA",
+	ColumnSuffix:  "Suffix",
+	ColumnStarted: "Started",
+}
+
+// ColumnDecorator returns the decorator printing a built-in column.
+func ColumnDecorator(col Column) Decorator {
+	return Decorator{
+		Name: columnNames[col],
+		Render: func(c DecorContext) string {
+			for _, field := range c.Segments.fields() {
+				if field.col == col {
+					return *field.ptr
+				}
+			}
+			return ""
+		},
+	}
+}
+
+// DefaultDecorators returns the decorators of the default line: spinner, label, bar, percent,
+// counter, rate, start time, elapsed, ETA and suffix. Append your own to add segments, e.g.
+//
+//	WithDecorators(append(multibar.DefaultDecorators(), myDecorator)...)
+func DefaultDecorators() []Decorator {
+	var s Segments
+	decorators := make([]Decorator, 0, len(columnNames))
+	for _, field := range s.fields() {
+		decorators = append(decorators, ColumnDecorator(field.col))
+	}
+	return decorators
+}
+
+// WithDecorators replaces the segments of every line with the given decorators.
+// Custom segments are also available to WithLayout as {{.Extra.name}}.
+func WithDecorators(decorators ...Decorator) Option {
+	return func(m *MultiBar) {
+		m.decorators = decorators
+	}
+}
+
+// decorate renders the segments of every line with the frame decorators, aligning them
+// across lines, and stores named custom segments in Segments.Extra.
+func decorate(bars []*Bar, segs []Segments, decorators []Decorator) [][]string {
+	values := make([][]string, len(segs))
+	for i := range segs {
+		values[i] = make([]string, len(decorators))
+		for j, d := range decorators {
+			values[i][j] = d.Render(DecorContext{Bar: bars[i], Segments: &segs[i]})
+		}
+	}
+	for j, d := range decorators {
+		if d.Align == AlignNone {
+			continue
+		}
+		width := 0
+		for i := range values {
+			width = max(width, visibleWidth(values[i][j]))
+		}
+		for i := range values {
+			if pad := width - visibleWidth(values[i][j]); pad > 0 && d.Align == AlignLeft {
+				values[i][j] += strings.Repeat(" ", pad)
+			} else {
+				values[i][j] = padLeft(values[i][j], width)
+			}
+		}
+	}
+	for j, d := range decorators {
+		if d.Name == "" {
+			continue
+		}
+		for i := range segs {
+			if segs[i].Extra == nil {
+				segs[i].Extra = make(map[string]string)
+			}
+			segs[i].Extra[d.Name] = values[i][j]
+		}
+	}
+	return values
+}
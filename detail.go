@@ -0,0 +1,51 @@
+package multibar
+
+// BarDetail gives the bar a detail line below it, produced by detail on every frame,
+// e.g. the current item or b.SpeedGraph(30). Empty output leaves the line out.
+func BarDetail(detail func(b *Bar) string) BarOption {
+	return func(b *Bar) {
+		b.detail = detail
+	}
+}
+
+// SetDetail sets the detail line of the bar, see BarDetail. Nil removes it.
+func (b *Bar) SetDetail(detail func(b *Bar) string) {
+	b.mu.Lock()
+	b.detail = detail
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// SpeedGraph returns a sparkline of the rate of the bar over the recent samples,
+// one cell per sample, newest on the right, padded to width cells.
+func (b *Bar) SpeedGraph(width int) string {
+	b.mu.Lock()
+	samples := append([]rateSample(nil), b.rates.samples...)
+	b.mu.Unlock()
+	var rates []float64
+	for i := 1; i < len(samples); i++ {
+		d := samples[i].at.Sub(samples[i-1].at).Seconds()
+		if d > 0 {
+			rates = append(rates, float64(samples[i].value-samples[i-1].value)/d)
+		}
+	}
+	if len(rates) > width {
+		rates = rates[len(rates)-width:]
+	}
+	highest := 0.0
+	for _, r := range rates {
+		highest = max(highest, r)
+	}
+	graph := make([]rune, 0, width)
+	for range width - len(rates) {
+		graph = append(graph, ' ')
+	}
+	for _, r := range rates {
+		level := 0
+		if highest > 0 && r > 0 {
+			level = max(int(r/highest*8+0.5), 1)
+		}
+		graph = append(graph, levelBlocks[level])
+	}
+	return string(graph)
+}
@@ -0,0 +1,162 @@
+package multibar
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// colorDepth is the number of colors a terminal is expected to show.
+type colorDepth int
+
+const (
+	colorNone      colorDepth = iota // NO_COLOR set or a dumb terminal
+	color16                          // basic SGR colors, used by the default themes
+	color256                         // 256-color palette, e.g. the stalled amber
+	colorTrueColor                   // 24-bit colors, needed by Theme.Gradient
+)
+
+func (d colorDepth) String() string {
+	switch d {
+	case color16:
+		return "16 colors"
+	case color256:
+		return "256 colors"
+	case colorTrueColor:
+		return "truecolor"
+	default:
+		return "no color"
+	}
+}
+
+// terminalInfo is what Diagnose detects about the environment.
+type terminalInfo struct {
+	stdoutTTY, stderrTTY bool
+	writer               string // where New draws by default
+	width, height        int
+	term, program        string
+	colors               colorDepth
+	unicode              bool
+}
+
+// detectTerminal inspects the standard streams and the environment.
+func detectTerminal() terminalInfo {
+	info := terminalInfo{
+		stdoutTTY: term.IsTerminal(int(os.Stdout.Fd())),
+		stderrTTY: term.IsTerminal(int(os.Stderr.Fd())),
+		writer:    "stdout",
+		term:      os.Getenv("TERM"),
+		program:   strings.TrimSpace(os.Getenv("TERM_PROGRAM") + " " + os.Getenv("TERM_PROGRAM_VERSION")),
+		colors:    detectColors(),
+		unicode:   detectUnicode(),
+	}
+	w := defaultWriter()
+	if w == os.Stderr {
+		info.writer = "stderr"
+	}
+	info.width, info.height = detectSize(w)
+	return info
+}
+
+// detectColors guesses the color depth from NO_COLOR, COLORTERM and TERM.
+func detectColors() colorDepth {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return colorNone
+	}
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return colorTrueColor
+	}
+	t := os.Getenv("TERM")
+	switch {
+	case strings.Contains(t, "256color"):
+		return color256
+	case t == "dumb":
+		return colorNone
+	case t == "" && runtime.GOOS != "windows":
+		return colorNone
+	default:
+		return color16
+	}
+}
+
+// detectUnicode reports whether the locale is UTF-8, so block and spinner glyphs show up.
+func detectUnicode() bool {
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
+
+// Diagnose writes what was detected about the terminal and how bars will be rendered,
+// for triaging reports of broken output.
+func Diagnose(w io.Writer) {
+	info := detectTerminal()
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+	size := "unknown"
+	if info.width > 0 {
+		size = fmt.Sprintf("%dx%d", info.width, info.height)
+	}
+	fmt.Fprintf(w, "stdout TTY:   %s\n", yesNo(info.stdoutTTY))
+	fmt.Fprintf(w, "stderr TTY:   %s\n", yesNo(info.stderrTTY))
+	fmt.Fprintf(w, "output:       %s\n", info.writer)
+	fmt.Fprintf(w, "size:         %s\n", size)
+	fmt.Fprintf(w, "TERM:         %s\n", orUnset(info.term))
+	fmt.Fprintf(w, "program:      %s\n", orUnset(info.program))
+	fmt.Fprintf(w, "colors:       %s\n", info.colors)
+	fmt.Fprintf(w, "unicode:      %s\n", yesNo(info.unicode))
+
+	profile := "interactive: bars redrawn in place"
+	if plainOutput(defaultWriter()) {
+		profile = fmt.Sprintf("plain: a line per bar every %s, no escape sequences", defaultPlainInterval)
+	}
+	fmt.Fprintf(w, "profile:      %s\n", profile)
+
+	var warnings []string
+	if !info.unicode {
+		warnings = append(warnings, "locale is not UTF-8: bar blocks and spinners may show as garbage")
+	}
+	if info.colors < color16 {
+		warnings = append(warnings, "colors look unsupported: use WithTheme(MonochromeTheme())")
+	} else if info.colors < colorTrueColor {
+		warnings = append(warnings, "no truecolor: Theme.Gradient colors will be approximated or wrong")
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "warning:      %s\n", warning)
+	}
+
+	// Samples let the reporter see what their terminal makes of our glyphs and colors
+	fmt.Fprintf(w, "blocks:       %s\n", string(partialBlocks[1:]))
+	fmt.Fprintf(w, "spinner:      %s\n", strings.Join(SpinnerDots, ""))
+	var states []string
+	for s := StatePaused; s <= StateUploading; s++ {
+		states = append(states, defaultStateGlyphs[s].Glyph)
+	}
+	fmt.Fprintf(w, "states:       %s %s\n", strings.Join(states, " "), failedGlyph)
+	fmt.Fprintf(w, "palette:      %s %s %s %s\n",
+		Style(colorGreen).Render("green"), Style(colorRed).Render("red"),
+		SGR(38, 5, 214).Render("amber"), GradientTrafficLight[0].Style().Render("truecolor"))
+}
+
+// orUnset returns s, or "(unset)" when it is empty.
+func orUnset(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return s
+}
@@ -0,0 +1,61 @@
+package multibar
+
+// ChangeKind identifies a change between two snapshots of a bar.
+type ChangeKind string
+
+const (
+	ChangeStarted  ChangeKind = "started"  // bar appeared
+	ChangeAdvanced ChangeKind = "advanced" // value changed by Delta
+	ChangeFinished ChangeKind = "finished"
+	ChangeFailed   ChangeKind = "failed"
+	ChangeRemoved  ChangeKind = "removed" // bar disappeared
+)
+
+// Change is a structured difference produced by DiffSnapshots.
+type Change struct {
+	Kind        ChangeKind `json:"kind"`
+	ID          int        `json:"id"`
+	Description string     `json:"description"`
+	Delta       int64      `json:"delta,omitempty"` // value difference for ChangeAdvanced, negative on regression
+}
+
+// DiffSnapshots compares two results of (*MultiBar).Snapshots, matching bars by ID.
+// Changes are listed in the order of bars in b, followed by bars removed since a.
+func DiffSnapshots(a, b []BarSnapshot) []Change {
+	before := make(map[int]BarSnapshot, len(a))
+	for _, s := range a {
+		before[s.ID] = s
+	}
+	var changes []Change
+	seen := make(map[int]bool, len(b))
+	for _, s := range b {
+		seen[s.ID] = true
+		change := Change{ID: s.ID, Description: s.Description}
+		prev, ok := before[s.ID]
+		if !ok {
+			change.Kind = ChangeStarted
+			changes = append(changes, change)
+			prev = BarSnapshot{}
+		}
+		if d := s.Value - prev.Value; d != 0 {
+			change.Kind = ChangeAdvanced
+			change.Delta = d
+			changes = append(changes, change)
+			change.Delta = 0
+		}
+		if s.Finished && !prev.Finished {
+			change.Kind = ChangeFinished
+			changes = append(changes, change)
+		}
+		if s.Failed && !prev.Failed {
+			change.Kind = ChangeFailed
+			changes = append(changes, change)
+		}
+	}
+	for _, s := range a {
+		if !seen[s.ID] {
+			changes = append(changes, Change{Kind: ChangeRemoved, ID: s.ID, Description: s.Description})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,62 @@
+package multibar
+
+import (
+	"fmt"
+	"time"
+)
+
+// DurationFormatter formats the elapsed and ETA columns.
+type DurationFormatter func(time.Duration) string
+
+// WithDurationFormat sets how durations are displayed, e.g. FormatCompact.
+func WithDurationFormat(format DurationFormatter) Option {
+	return func(m *MultiBar) {
+		if format != nil {
+			m.durationFmt = format
+		}
+	}
+}
+
+// FormatClock formats d as H:MM:SS, e.g. 1:02:03. It is the default.
+func FormatClock(d time.Duration) string {
+	totalSeconds := int64(d.Seconds())
+	hours := totalSeconds / 3600
+	minutes := (totalSeconds % 3600) / 60
+	seconds := totalSeconds % 60
+	return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+}
+
+// FormatDays formats d as H:MM:SS with a day count for multi-day durations, e.g. 2d03:04:05.
+func FormatDays(d time.Duration) string {
+	totalSeconds := int64(d.Seconds())
+	days := totalSeconds / 86400
+	if days == 0 {
+		return FormatClock(d)
+	}
+	rest := time.Duration(totalSeconds%86400) * time.Second
+	return fmt.Sprintf("%dd%02d:%02d:%02d", days, int64(rest.Hours()), int64(rest.Minutes())%60, int64(rest.Seconds())%60)
+}
+
+// FormatCompact formats d with the two most significant units, e.g. 45s, 3m12s, 1h05m, 2d07h.
+func FormatCompact(d time.Duration) string {
+	totalSeconds := int64(d.Seconds())
+	days := totalSeconds / 86400
+	hours := (totalSeconds % 86400) / 3600
+	minutes := (totalSeconds % 3600) / 60
+	seconds := totalSeconds % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%02dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%02ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+// FormatMillis formats d as H:MM:SS.mmm for fast tasks, e.g. 0:00:03.142.
+func FormatMillis(d time.Duration) string {
+	return fmt.Sprintf("%s.%03d", FormatClock(d), d.Milliseconds()%1000)
+}
@@ -0,0 +1,116 @@
+package multibar
+
+import "time"
+
+// Estimator predicts the remaining time of a bar from its progress.
+// Calls are serialized by the bar, so implementations need no locking.
+type Estimator interface {
+	// ObserveProgress records that value out of max was reached at t.
+	ObserveProgress(t time.Time, value, max int64)
+	// Remaining returns the estimated time left at now, or false if it is not known yet.
+	Remaining(now time.Time) (time.Duration, bool)
+}
+
+// linearEstimator scales the time spent so far by the work left.
+type linearEstimator struct {
+	start      time.Time
+	value, max int64
+}
+
+// NewLinearEstimator returns the default estimator: remaining = elapsed * (max - value) / value.
+func NewLinearEstimator() Estimator {
+	return &linearEstimator{}
+}
+
+func (e *linearEstimator) ObserveProgress(t time.Time, value, max int64) {
+	// A rollback to 0 keeps the clock, only restart starts it over
+	if e.start.IsZero() {
+		e.start = t
+	}
+	e.value, e.max = value, max
+}
+
+func (e *linearEstimator) restart() {
+	e.start = time.Time{}
+}
+
+func (e *linearEstimator) Remaining(now time.Time) (time.Duration, bool) {
+	if e.max == Undefined || e.value <= 0 {
+		return 0, false
+	}
+	elapsed := now.Sub(e.start)
+	return max(time.Duration(float64(elapsed)*float64(e.max-e.value)/float64(e.value)), 0), true
+}
+
+// windowEstimator divides the work left by the rate over a recent window.
+type windowEstimator struct {
+	linearEstimator
+	rates rateTracker
+}
+
+// NewWindowEstimator returns an estimator using the progress rate over the last window,
+// so the ETA follows changes in throughput. It falls back to the linear estimate while the rate is zero.
+func NewWindowEstimator(window time.Duration) Estimator {
+	if window <= 0 {
+		window = defaultRateWindow
+	}
+	return &windowEstimator{rates: rateTracker{window: window}}
+}
+
+func (e *windowEstimator) ObserveProgress(t time.Time, value, max int64) {
+	if e.start.IsZero() {
+		e.rates.reset(t, value)
+	} else {
+		e.rates.observe(t, value)
+	}
+	e.linearEstimator.ObserveProgress(t, value, max)
+}
+
+func (e *windowEstimator) Remaining(now time.Time) (time.Duration, bool) {
+	if e.max == Undefined || e.value <= 0 {
+		return 0, false
+	}
+	if rate := e.rates.rate(now, e.value); rate > 0 {
+		return max(time.Duration(float64(e.max-e.value)/rate*float64(time.Second)), 0), true
+	}
+	return e.linearEstimator.Remaining(now)
+}
+
+// restarter is implemented by estimators that can start over for a new run of a bar kept
+// by Reset, see (*Bar).SetEstimator.
+type restarter interface {
+	restart()
+}
+
+// WithEstimator sets the factory creating an Estimator for every new bar.
+func WithEstimator(newEstimator func() Estimator) Option {
+	return func(m *MultiBar) {
+		if newEstimator != nil {
+			m.newEstimator = newEstimator
+		}
+	}
+}
+
+// SetEstimator replaces the ETA estimator of this bar. The estimator is kept by Reset.
+func (b *Bar) SetEstimator(e Estimator) {
+	b.mu.Lock()
+	b.estimator = e
+	b.newEstimator = nil
+	if r, ok := e.(restarter); ok {
+		r.restart()
+	}
+	e.ObserveProgress(b.startedAt, 0, b.max)
+	if b.value != 0 {
+		e.ObserveProgress(b.activeLocked(time.Now()), b.value, b.max)
+	}
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// observeLocked feeds the current progress to the estimator.
+func (b *Bar) observeLocked(now time.Time) {
+	b.stallNotified = false
+	active := b.activeLocked(now)
+	b.rates.observe(active, b.value)
+	b.estimator.ObserveProgress(active, b.value, b.max)
+}
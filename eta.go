@@ -0,0 +1,37 @@
+package multibar
+
+import "time"
+
+// ETAMode selects what the rightmost column shows.
+type ETAMode int
+
+const (
+	ETATotal     ETAMode = iota + 1 // estimated total time (default)
+	ETARemaining                    // estimated time left
+)
+
+// WithETAMode sets the ETA column mode for all bars.
+func WithETAMode(mode ETAMode) Option {
+	return func(m *MultiBar) {
+		m.etaMode = mode
+	}
+}
+
+// WithETAWindow estimates the ETA from the progress rate over the last window instead of the whole run,
+// so it follows changes in throughput. The window also applies to the rate column.
+func WithETAWindow(window time.Duration) Option {
+	return func(m *MultiBar) {
+		if window > 0 {
+			m.rateWindowSize = window
+			m.newEstimator = func() Estimator { return NewWindowEstimator(window) }
+		}
+	}
+}
+
+// SetETAMode overrides the ETA column mode of this bar. Zero restores the MultiBar setting.
+func (b *Bar) SetETAMode(mode ETAMode) {
+	b.mu.Lock()
+	b.etaMode = mode
+	b.mu.Unlock()
+	b.redraw()
+}
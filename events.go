@@ -0,0 +1,201 @@
+package multibar
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventKind identifies what happened to a bar.
+type EventKind string
+
+const (
+	EventCreated     EventKind = "created"
+	EventProgress    EventKind = "progress"
+	EventDescription EventKind = "description"
+	EventFinished    EventKind = "finished"
+	EventRemoved     EventKind = "removed"
+	EventAborted     EventKind = "aborted"
+	EventFailed      EventKind = "failed"
+)
+
+// Event is a bar state change delivered to event sinks.
+type Event struct {
+	Time time.Time   `json:"ts"`
+	Kind EventKind   `json:"event"`
+	Bar  BarSnapshot `json:"bar"`
+}
+
+// EventSink receives bar events. Emit may be called from multiple goroutines.
+type EventSink interface {
+	Emit(e Event)
+}
+
+// progressEventInterval limits how often progress events are emitted per bar.
+const progressEventInterval = 100 * time.Millisecond
+
+// WithEventSink delivers bar events to sink in addition to the terminal output.
+func WithEventSink(sink EventSink) Option {
+	return func(m *MultiBar) {
+		m.sinks = append(m.sinks, sink)
+	}
+}
+
+// WithLogfmtEvents writes bar events to w as logfmt lines:
+//
+//	ts=2024-01-02T15:04:05.000Z event=progress id=3 bar=file1.zip value=42 max=100 elapsed=1.2s rate=35.00
+func WithLogfmtEvents(w io.Writer) Option {
+	return WithEventSink(NewLogfmtSink(w))
+}
+
+type logfmtSink struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewLogfmtSink returns an EventSink writing logfmt lines to w.
+func NewLogfmtSink(w io.Writer) EventSink {
+	return &logfmtSink{w: w}
+}
+
+func (s *logfmtSink) Emit(e Event) {
+	var sb strings.Builder
+	sb.WriteString("ts=" + e.Time.UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+	sb.WriteString(" event=" + string(e.Kind))
+	sb.WriteString(" id=" + strconv.Itoa(e.Bar.ID))
+	sb.WriteString(" bar=" + logfmtValue(e.Bar.Description))
+	sb.WriteString(" value=" + strconv.FormatInt(e.Bar.Value, 10))
+	sb.WriteString(" max=" + strconv.FormatInt(e.Bar.Max, 10))
+	sb.WriteString(" elapsed=" + e.Bar.Elapsed.Round(time.Millisecond).String())
+	sb.WriteString(" rate=" + strconv.FormatFloat(e.Bar.Rate, 'f', 2, 64))
+	if e.Bar.Finished {
+		sb.WriteString(" finished=true")
+	}
+	if e.Bar.Failed {
+		sb.WriteString(" failed=true")
+	}
+	sb.WriteByte('\n')
+
+	s.mu.Lock()
+	io.WriteString(s.w, sb.String())
+	s.mu.Unlock()
+}
+
+type jsonSink struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewJSONSink returns an EventSink writing each event to w as a line of JSON:
+//
+//	{"ts":"2024-01-02T15:04:05Z","event":"progress","bar":{"id":3,"description":"file1.zip","value":42,"max":100,...}}
+func NewJSONSink(w io.Writer) EventSink {
+	return &jsonSink{w: w}
+}
+
+func (s *jsonSink) Emit(e Event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.w.Write(append(data, '\n'))
+	s.mu.Unlock()
+}
+
+// WithJSONEvents writes the JSON event stream (see NewJSONSink) to w in addition to the terminal display.
+func WithJSONEvents(w io.Writer) Option {
+	return WithEventSink(NewJSONSink(w))
+}
+
+// WithJSONOutput writes the JSON event stream to w instead of drawing bars, for wrapper tools
+// and GUI frontends consuming progress of a CLI, e.g. behind a --json flag.
+func WithJSONOutput(w io.Writer) Option {
+	return func(m *MultiBar) {
+		m.sinks = append(m.sinks, NewJSONSink(w))
+		m.headless = true
+	}
+}
+
+// WithProgressFD writes the JSON event stream (see NewJSONSink) to an extra file descriptor
+// inherited from the parent, e.g. "3>progress.jsonl" in a shell. The terminal display is not affected.
+func WithProgressFD(fd uintptr) Option {
+	return func(m *MultiBar) {
+		if f := os.NewFile(fd, "progress"); f != nil {
+			m.sinks = append(m.sinks, NewJSONSink(f))
+		}
+	}
+}
+
+// logfmtValue quotes v if it contains characters that are not allowed in a bare logfmt value.
+func logfmtValue(v string) string {
+	if v == "" || strings.ContainsAny(v, " =\"\t\r\n\\") {
+		return strconv.Quote(v)
+	}
+	return v
+}
+
+// emit delivers an event to all sinks.
+func (m *MultiBar) emit(kind EventKind, s BarSnapshot) {
+	m.mu.Lock()
+	sinks := m.sinks
+	m.mu.Unlock()
+	if len(sinks) == 0 {
+		return
+	}
+	e := Event{Time: time.Now(), Kind: kind, Bar: s}
+	for _, sink := range sinks {
+		sink.Emit(e)
+	}
+}
+
+// updateEventLocked returns the event caused by a value change, if one is due.
+func (b *Bar) updateEventLocked(now time.Time, wasFinished bool) (EventKind, BarSnapshot, bool) {
+	if b.finished && !wasFinished {
+		b.lastEventAt = now
+		return EventFinished, b.snapshotLocked(now), true
+	}
+	s, ok := b.progressEventLocked(now)
+	return EventProgress, s, ok
+}
+
+// progressEventLocked decides whether a progress event is due and returns its snapshot.
+func (b *Bar) progressEventLocked(now time.Time) (BarSnapshot, bool) {
+	if now.Sub(b.lastEventAt) < progressEventInterval {
+		b.eventPending = true
+		return BarSnapshot{}, false
+	}
+	b.lastEventAt, b.eventPending = now, false
+	return b.snapshotLocked(now), true
+}
+
+// flushProgressEvents emits the progress events held back by the throttle once they are due,
+// or all of them when final, so the last value before a bar goes idle reaches the sinks.
+func (m *MultiBar) flushProgressEvents(now time.Time, final bool) {
+	m.mu.Lock()
+	bars := slices.Clone(m.bars)
+	hasSinks := len(m.sinks) > 0
+	m.mu.Unlock()
+	if !hasSinks {
+		return
+	}
+	for _, b := range bars {
+		b.mu.Lock()
+		// Finished bars already reported their last value
+		due := b.eventPending && !b.finished && (final || now.Sub(b.lastEventAt) >= progressEventInterval)
+		var snap BarSnapshot
+		if due {
+			b.lastEventAt, b.eventPending = now, false
+			snap = b.snapshotLocked(now)
+		}
+		b.mu.Unlock()
+		if due {
+			m.emit(EventProgress, snap)
+		}
+	}
+}
@@ -23,19 +23,22 @@ var demoFiles = []File{
 
 func main() {
 	mb := multibar.New()
+	defer mb.Stop()
 	workBar := mb.NewBar(multibar.Undefined, "Working")
 	workersBar := mb.NewBar(len(demoFiles), fmt.Sprintf("Workers (0/%d)", len(demoFiles)))
 	var totalSize int64
 	for _, file := range demoFiles {
 		totalSize += file.Size
 	}
-	bytesBar := mb.NewBar64(totalSize, "Total bytes")
+	bytesBar := mb.NewBytesBar(totalSize, "Total bytes")
+	bytesBar.SetRole(multibar.RolePrimary)
 
 	mb.Start()
 
 	var wg sync.WaitGroup
 	for _, file := range demoFiles {
 		fileBar := mb.NewBar64(file.Size, file.Name)
+		fileBar.SetRole(multibar.RoleDetail)
 		wg.Add(1)
 		go func(b *multibar.Bar, f File) {
 			for j := 0; j < int(file.Size); j++ {
@@ -0,0 +1,34 @@
+package multibar
+
+import "time"
+
+// failedGlyph replaces the spinner of failed and aborted bars.
+const failedGlyph = "✗"
+
+// Fail finishes the bar with an error: the bar turns red, ✗ replaces the spinner and the
+// message of err, if any, is shown after the time columns. Failed bars stay on screen even
+// with WithRemoveFinished and count as failed in Outcome and the summary report.
+func (b *Bar) Fail(err error) {
+	b.mu.Lock()
+	if b.finished || b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	b.updatedAt = time.Now()
+	b.finished = true
+	b.failed, b.err = true, err
+	b.lastEventAt = b.updatedAt
+	snap := b.snapshotLocked(b.updatedAt)
+	b.mu.Unlock()
+	b.mb.emit(EventFailed, snap)
+	b.redraw(true)
+	b.runFinishHooks()
+}
+
+// errorText returns the message of err, or "" for nil.
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
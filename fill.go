@@ -0,0 +1,34 @@
+package multibar
+
+import (
+	"strings"
+	"sync"
+)
+
+// fillTables caches the fill strings of determinate bars per width, see fillTable.
+var fillTables sync.Map // int → []string
+
+// fillTable returns the fill strings of a bar width cells wide for every filled unit count
+// from 0 to width*8, each cell having 8 partial steps. Styles are applied on top, so one
+// table serves every theme.
+func fillTable(width int) []string {
+	if t, ok := fillTables.Load(width); ok {
+		return t.([]string)
+	}
+	t := make([]string, width*8+1)
+	full := string(partialBlocks[8])
+	for units := range t {
+		fullChars, remainder := units/8, units%8
+		var sb strings.Builder
+		sb.WriteString(strings.Repeat(full, fullChars))
+		empty := width - fullChars
+		if remainder > 0 {
+			sb.WriteRune(partialBlocks[remainder])
+			empty--
+		}
+		sb.WriteString(strings.Repeat(string(partialBlocks[0]), empty))
+		t[units] = sb.String()
+	}
+	actual, _ := fillTables.LoadOrStore(width, t)
+	return actual.([]string)
+}
@@ -0,0 +1,54 @@
+package multibar
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FloatScale is the number of units per 1.0 of float bars: their values are kept as int64
+// millionths, so repeated fractional updates add up exactly. Snapshots of float bars hold
+// scaled values.
+const FloatScale = 1_000_000
+
+// NewBarFloat creates a bar with fractional progress, e.g. 0.0–1.0 or training epochs.
+// Its counter, rate and the summary report show the fractional values, see UnitFloat.
+// Use SetValueFloat and AddFloat to update it; Undefined max works as with NewBar.
+func (m *MultiBar) NewBarFloat(maxValue float64, description string, opts ...BarOption) *Bar {
+	max := int64(Undefined)
+	if maxValue != Undefined {
+		max = toFixed(maxValue)
+	}
+	return m.NewBar64(max, description, append([]BarOption{BarUnit(UnitFloat)}, opts...)...)
+}
+
+// SetValueFloat sets the value of a bar created with NewBarFloat.
+func (b *Bar) SetValueFloat(v float64) {
+	b.SetValue(toFixed(v))
+}
+
+// AddFloat adds d to the value of a bar created with NewBarFloat.
+func (b *Bar) AddFloat(d float64) {
+	b.Add(toFixed(d))
+}
+
+// ValueFloat returns the value of a bar created with NewBarFloat.
+func (b *Bar) ValueFloat() float64 {
+	return float64(b.Value()) / FloatScale
+}
+
+// toFixed converts a float value to FloatScale units.
+func toFixed(v float64) int64 {
+	return int64(math.Round(v * FloatScale))
+}
+
+// formatFloat formats a float value compactly: 3 significant digits below 1, otherwise up to
+// 2 decimals, e.g. 0.0125, 0.5, 12.25.
+func formatFloat(v float64) string {
+	if v != 0 && math.Abs(v) < 1 {
+		return strconv.FormatFloat(v, 'g', 3, 64)
+	}
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,42 @@
+package multibar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WithSummaryFooter adds a line under all bars with overall progress: percent, combined rate,
+// finished/total bars and overall ETA. Planned bars are included, aggregates and gauges are not.
+func WithSummaryFooter() Option {
+	return func(m *MultiBar) {
+		m.footer = true
+	}
+}
+
+// footerLine renders the summary footer, truncated to width cells, 0 meaning unlimited.
+func footerLine(s summary, f *frame, now time.Time, width int) string {
+	theme := f.theme
+	parts := []string{theme.Label.Render("Total")}
+	parts = append(parts,
+		theme.Percent.Render(formatPercent(s.done, max(s.total, 1), s.total > 0 && s.done == s.total, f.percentDecimals)),
+		theme.Counter.Render(fmt.Sprintf("%d/%d done", s.finished, s.bars)),
+	)
+	if s.rate > 0 {
+		parts = append(parts, theme.Rate.Render(formatRate(s.rate, s.unit)))
+	}
+	if remaining, ok := s.eta(now); ok {
+		parts = append(parts, theme.ETA.Render("ETA "+f.formatDuration(remaining)))
+	}
+	line := strings.Join(parts, theme.separator())
+	indent := f.indent(f.spinner)
+	if width > 0 && visibleWidth(indent+line) > width-1 {
+		// Styles are dropped rather than cut in the middle of an escape sequence
+		var plain []string
+		for _, p := range parts {
+			plain = append(plain, stripStyles(p))
+		}
+		return indent + truncateLabel(strings.Join(plain, theme.Separator), width-1-displayWidth(indent))
+	}
+	return indent + line
+}
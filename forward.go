@@ -0,0 +1,125 @@
+package multibar
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ForwardEnv is the environment variable telling a child process where to forward its bars.
+// New checks it: when set, the MultiBar sends its events to the parent instead of drawing.
+const ForwardEnv = "MULTIBAR_FORWARD"
+
+// Forward accepts bars forwarded by child processes and shows them in this MultiBar.
+// Pass the returned "MULTIBAR_FORWARD=..." entry in the environment of the child (exec.Cmd.Env).
+// Closing the returned io.Closer stops accepting children.
+func (m *MultiBar) Forward() (string, io.Closer, error) {
+	dir, err := os.MkdirTemp("", "multibar")
+	if err != nil {
+		return "", nil, err
+	}
+	path := filepath.Join(dir, "forward.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go m.receiveForwarded(conn)
+		}
+	}()
+	return ForwardEnv + "=" + path, &forwardListener{ln, dir}, nil
+}
+
+type forwardListener struct {
+	net.Listener
+	dir string
+}
+
+func (l *forwardListener) Close() error {
+	err := l.Listener.Close()
+	os.RemoveAll(l.dir)
+	return err
+}
+
+// receiveForwarded mirrors the events of one child into local bars.
+func (m *MultiBar) receiveForwarded(conn net.Conn) {
+	defer conn.Close()
+	bars := make(map[int]*Bar)
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		b, ok := bars[e.Bar.ID]
+		if e.Kind == EventRemoved {
+			if ok {
+				b.Remove()
+				delete(bars, e.Bar.ID)
+			}
+			continue
+		}
+		if !ok {
+			b = m.NewBar64(e.Bar.Max, e.Bar.Description, BarUnit(e.Bar.Unit))
+			bars[e.Bar.ID] = b
+		}
+		b.apply(e.Bar)
+	}
+}
+
+// apply updates the bar to match a forwarded snapshot.
+func (b *Bar) apply(s BarSnapshot) {
+	if b.Snapshot().Description != s.Description {
+		b.SetDescription(s.Description)
+	}
+	if b.Max() != s.Max {
+		b.SetMax(s.Max)
+	}
+	if b.Value() != s.Value {
+		b.SetValue(s.Value)
+	}
+	if b.Status() != s.Status {
+		b.SetStatus(s.Status)
+	}
+	// Failed alone may mean a value above max, which the value already shows
+	failed := s.Failed && (s.Error != "" || s.Max == Undefined || s.Value <= s.Max)
+	switch {
+	case s.Finished && failed:
+		var err error
+		if s.Error != "" {
+			err = errors.New(s.Error)
+		}
+		b.Fail(err)
+	case s.Finished:
+		b.Finish()
+	case s.Aborted:
+		b.markAborted(time.Now())
+	}
+}
+
+// connectForward switches the MultiBar to forwarding if the parent left an address in the environment.
+func (m *MultiBar) connectForward() {
+	path := os.Getenv(ForwardEnv)
+	if path == "" {
+		return
+	}
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return
+	}
+	m.sinks = append(m.sinks, NewJSONSink(conn))
+	m.forwardConn = conn
+	m.forwarding = true
+}
@@ -0,0 +1,41 @@
+package multibar
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestForwardFailedChild(t *testing.T) {
+	parent := New(WithDisabled(true))
+	defer parent.Stop()
+	conn, childConn := net.Pipe()
+	received := make(chan struct{})
+	go func() {
+		parent.receiveForwarded(conn)
+		close(received)
+	}()
+
+	child := New(WithDisabled(true), WithEventSink(NewJSONSink(childConn)))
+	bar := child.NewBar(10, "upload")
+	bar.SetStatus("chunk 3")
+	bar.Add(4)
+	bar.Fail(errors.New("disk full"))
+	child.Stop()
+	childConn.Close()
+	<-received
+
+	parent.mu.Lock()
+	bars := parent.bars
+	parent.mu.Unlock()
+	if len(bars) != 1 {
+		t.Fatalf("parent has %d bars, want 1", len(bars))
+	}
+	s := bars[0].Snapshot()
+	if !s.Finished || !s.Failed || s.Error != "disk full" {
+		t.Errorf("forwarded bar: Finished=%v Failed=%v Error=%q, want failed with %q", s.Finished, s.Failed, s.Error, "disk full")
+	}
+	if s.Value != 4 || s.Status != "chunk 3" {
+		t.Errorf("forwarded bar: Value=%d Status=%q, want 4 and %q", s.Value, s.Status, "chunk 3")
+	}
+}
@@ -0,0 +1,83 @@
+package multibar
+
+import "sync"
+
+// Gauge is a bar whose value freely rises and falls within [min, max], e.g. queue length or
+// in-flight requests. It never finishes and has no ETA; values outside the bounds are clamped.
+type Gauge struct {
+	bar *Bar
+	mu  sync.Mutex
+}
+
+// NewGauge creates a gauge with the given bounds. The fill and percent show the position
+// of the value within the bounds, the counter shows the value itself.
+// Gauges are not counted in aggregates and overall progress.
+func (m *MultiBar) NewGauge(minValue, maxValue int64, description string, opts ...BarOption) *Gauge {
+	opts = widgetOptions(func(b *Bar) {
+		b.gauge = true
+		b.lower = minValue
+		b.value = minValue
+	}, opts)
+	return &Gauge{bar: m.NewBar64(max(maxValue, minValue+1), description, opts...)}
+}
+
+// widgetOptions puts the setup of bars that show measurements rather than work, like gauges,
+// histograms and queues, before the caller's options: rate and ETA hidden, no stall detection.
+func widgetOptions(setup func(b *Bar), opts []BarOption) []BarOption {
+	return append([]BarOption{BarHiddenColumns(gaugeColumns), func(b *Bar) {
+		b.widget = true
+		b.stallTimeout = 0
+		setup(b)
+	}}, opts...)
+}
+
+// workLocked reports whether the bar tracks work to be done, unlike aggregates and widgets.
+func (b *Bar) workLocked() bool {
+	return b.derive == nil && !b.gauge && !b.widget
+}
+
+// Set sets the value of the gauge.
+func (g *Gauge) Set(value int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.set(value)
+}
+
+// Add changes the value of the gauge by delta, which may be negative.
+func (g *Gauge) Add(delta int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.set(g.bar.Value() + delta)
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() int64 {
+	return g.bar.Value()
+}
+
+// SetBounds changes the bounds of the gauge, clamping the current value into them.
+func (g *Gauge) SetBounds(minValue, maxValue int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b := g.bar
+	maxValue = max(maxValue, minValue+1)
+	b.mu.Lock()
+	b.lower = minValue
+	b.mu.Unlock()
+	// Clamp before moving max, so the value never exceeds it
+	b.SetValue(min(max(b.Value(), minValue), maxValue))
+	b.SetMax(maxValue)
+}
+
+// Bar returns the bar the gauge is displayed in.
+func (g *Gauge) Bar() *Bar {
+	return g.bar
+}
+
+func (g *Gauge) set(value int64) {
+	b := g.bar
+	b.mu.Lock()
+	value = min(max(value, b.lower), b.max)
+	b.mu.Unlock()
+	b.SetValue(value)
+}
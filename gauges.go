@@ -0,0 +1,79 @@
+package multibar
+
+import (
+	"math"
+	"runtime/metrics"
+	"sync"
+)
+
+const (
+	metricHeapInUse = "/memory/classes/heap/objects:bytes"
+	metricMemLimit  = "/gc/gomemlimit:bytes"
+	metricHeapTotal = "/memory/classes/total:bytes"
+	metricGCCPU     = "/cpu/classes/gc/total:cpu-seconds"
+	metricTotalCPU  = "/cpu/classes/total:cpu-seconds"
+)
+
+// gaugeColumns are meaningless for gauges and hidden by default.
+const gaugeColumns = ColumnRate | ColumnETA
+
+// NewHeapGauge creates a bar showing heap in use against GOMEMLIMIT, or against all memory
+// mapped by the runtime when no limit is set. It updates itself every frame and never finishes.
+func (m *MultiBar) NewHeapGauge(description string, opts ...BarOption) *Bar {
+	samples := []metrics.Sample{{Name: metricHeapInUse}, {Name: metricMemLimit}, {Name: metricHeapTotal}}
+	var mu sync.Mutex
+	derive := func() (value, maxValue int64, done bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		metrics.Read(samples)
+		value = int64(sampleUint(samples[0]))
+		limit := sampleUint(samples[1])
+		if limit == 0 || limit >= math.MaxInt64 {
+			limit = sampleUint(samples[2])
+		}
+		return value, int64(limit), false
+	}
+	return m.newGauge(description, derive, append([]BarOption{BarUnit(UnitBytes)}, opts...))
+}
+
+// NewGCGauge creates a bar showing the fraction of CPU time spent in the garbage collector.
+// The runtime refreshes CPU metrics on every GC cycle, so the gauge shows the fraction
+// since the previous refresh. It updates itself every frame and never finishes.
+func (m *MultiBar) NewGCGauge(description string, opts ...BarOption) *Bar {
+	samples := []metrics.Sample{{Name: metricGCCPU}, {Name: metricTotalCPU}}
+	var mu sync.Mutex
+	var lastGC, lastTotal float64
+	var permille int64
+	derive := func() (value, maxValue int64, done bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		metrics.Read(samples)
+		gc, total := sampleFloat(samples[0]), sampleFloat(samples[1])
+		if total > lastTotal {
+			permille = int64(math.Round((gc - lastGC) / (total - lastTotal) * 1000))
+			permille = min(max(permille, 0), 1000)
+			lastGC, lastTotal = gc, total
+		}
+		return permille, 1000, false
+	}
+	return m.newGauge(description, derive, opts)
+}
+
+func (m *MultiBar) newGauge(description string, derive func() (int64, int64, bool), opts []BarOption) *Bar {
+	opts = append([]BarOption{BarHiddenColumns(gaugeColumns), func(b *Bar) { b.derive = derive }}, opts...)
+	return m.NewBar64(Undefined, description, opts...)
+}
+
+func sampleUint(s metrics.Sample) uint64 {
+	if s.Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return s.Value.Uint64()
+}
+
+func sampleFloat(s metrics.Sample) float64 {
+	if s.Value.Kind() != metrics.KindFloat64 {
+		return 0
+	}
+	return s.Value.Float64()
+}
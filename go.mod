@@ -1,3 +1,13 @@
 module github.com/metalim/multibar
 
 go 1.24.4
+
+require (
+	github.com/mattn/go-runewidth v0.0.30
+	golang.org/x/term v0.34.0
+)
+
+require (
+	github.com/clipperhouse/uax29/v2 v2.2.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
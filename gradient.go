@@ -0,0 +1,71 @@
+package multibar
+
+import "strings"
+
+// RGB is a truecolor value.
+type RGB struct {
+	R, G, B uint8
+}
+
+// Style returns the truecolor foreground style of the color.
+func (c RGB) Style() Style {
+	return SGR(38, 2, int(c.R), int(c.G), int(c.B))
+}
+
+// GradientMode selects how Theme.Gradient colors the fill of running bars.
+type GradientMode int
+
+const (
+	GradientNone     GradientMode = iota
+	GradientProgress              // the whole fill takes the color at the current progress
+	GradientAcross                // the gradient is painted across the filled cells
+)
+
+// GradientTrafficLight goes from red through yellow to green.
+var GradientTrafficLight = []RGB{{220, 50, 50}, {230, 200, 40}, {60, 200, 80}}
+
+// colorAt interpolates evenly spaced color stops at t in [0, 1].
+func colorAt(stops []RGB, t float64) RGB {
+	if len(stops) == 1 || t <= 0 {
+		return stops[0]
+	}
+	if t >= 1 {
+		return stops[len(stops)-1]
+	}
+	pos := t * float64(len(stops)-1)
+	i := int(pos)
+	frac := pos - float64(i)
+	a, b := stops[i], stops[i+1]
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*frac)
+	}
+	return RGB{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B)}
+}
+
+// paintGradient colors the fill of a running bar according to the theme gradient.
+// It returns false when the theme has no gradient.
+func paintGradient(bar string, value, maxVal int64, width int, theme *Theme) (string, bool) {
+	if theme.GradientMode == GradientNone || len(theme.Gradient) == 0 || maxVal <= 0 {
+		return bar, false
+	}
+	if theme.GradientMode == GradientProgress {
+		return colorAt(theme.Gradient, float64(value)/float64(maxVal)).Style().Render(bar), true
+	}
+	var sb strings.Builder
+	i := 0
+	for _, r := range bar {
+		if r == partialBlocks[0] {
+			sb.WriteRune(r)
+			continue
+		}
+		t := 0.0
+		if width > 1 {
+			t = float64(i) / float64(width-1)
+		}
+		sb.WriteString(string(colorAt(theme.Gradient, t).Style()))
+		sb.WriteRune(r)
+		i++
+	}
+	sb.WriteString(colorReset)
+	return sb.String(), true
+}
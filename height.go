@@ -0,0 +1,95 @@
+package multibar
+
+import (
+	"fmt"
+	"time"
+)
+
+// HeightFit selects which bars are drawn when they don't fit into the terminal height.
+type HeightFit int
+
+const (
+	FitActive HeightFit = iota // running bars first, in display order (default)
+	FitRotate                  // a window scrolling through all bars, one bar per second
+	FitNone                    // draw every bar, the terminal may scroll and corrupt the redraw
+)
+
+// fitRotateInterval is how often FitRotate advances its window by one bar.
+const fitRotateInterval = time.Second
+
+// WithHeightFit selects the bars drawn when the frame is taller than the terminal.
+// The others are summarized as "… and 42 more".
+func WithHeightFit(fit HeightFit) Option {
+	return func(m *MultiBar) {
+		m.heightFit = fit
+	}
+}
+
+// WithHeight sets the number of rows the frame must fit into instead of detecting the terminal height.
+// Zero restores detection.
+func WithHeight(rows int) Option {
+	return func(m *MultiBar) {
+		m.height = rows
+	}
+}
+
+// fitHeight picks the blocks of bars to draw within rows lines, reserving a line for the
+// "… and N more" note when some are left out. It returns the picked blocks in display order
+// and how many bars were left out.
+func fitHeight(bars []*Bar, blocks [][]string, rows int, fit HeightFit, now time.Time) ([][]string, int) {
+	total := 0
+	for _, block := range blocks {
+		total += len(block)
+	}
+	if total <= rows || len(blocks) == 0 {
+		return blocks, 0
+	}
+	// The header and trailer may take up all the rows
+	budget := max(rows-1, 0)
+	picked := make([]bool, len(blocks))
+	count := 0
+	take := func(i int) bool {
+		if len(blocks[i]) > budget {
+			return false
+		}
+		budget -= len(blocks[i])
+		picked[i] = true
+		count++
+		return true
+	}
+	switch fit {
+	case FitRotate:
+		start := int(now.UnixNano()/int64(fitRotateInterval)) % len(blocks)
+		for k := range blocks {
+			if !take((start + k) % len(blocks)) {
+				break
+			}
+		}
+	default:
+		finished := make([]bool, len(bars))
+		for i, b := range bars {
+			b.mu.Lock()
+			finished[i] = b.finished
+			b.mu.Unlock()
+		}
+		for _, wantFinished := range []bool{false, true} {
+			for i := range blocks {
+				if finished[i] == wantFinished {
+					take(i)
+				}
+			}
+		}
+	}
+	shown := make([][]string, 0, count)
+	for i, block := range blocks {
+		if picked[i] {
+			shown = append(shown, block)
+		}
+	}
+	return shown, len(blocks) - count
+}
+
+// moreLine notes how many bars are not drawn.
+func moreLine(n int, f *frame) string {
+	return f.indent(f.spinner) + f.theme.Status.Render(fmt.Sprintf("%s and %d more", ellipsis, n))
+}
@@ -0,0 +1,85 @@
+package multibar
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var levelBlocks = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// Histogram shows the distribution of observed values, e.g. latencies, as block heights
+// in the bar column. Its value is the number of observations.
+type Histogram struct {
+	bar    *Bar
+	mu     sync.Mutex
+	bounds []float64
+	counts []int64
+}
+
+// NewHistogram creates a histogram with buckets ending at the given ascending upper bounds,
+// plus one bucket for values above the last bound, e.g. NewHistogram("latency ms", []float64{1, 5, 10, 50, 100}).
+func (m *MultiBar) NewHistogram(description string, bounds []float64, opts ...BarOption) *Histogram {
+	bounds = slices.Clone(bounds)
+	slices.Sort(bounds)
+	h := &Histogram{bounds: bounds, counts: make([]int64, len(bounds)+1)}
+	opts = widgetOptions(func(b *Bar) { b.draw = h.histogram }, opts)
+	h.bar = m.NewBar64(Undefined, description, opts...)
+	return h
+}
+
+// Observe counts the value in its bucket.
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	h.counts[sort.SearchFloat64s(h.bounds, v)]++
+	h.mu.Unlock()
+	h.bar.Add(1)
+}
+
+// Counts returns the number of observations per bucket, the last one counting values above all bounds.
+func (h *Histogram) Counts() []int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.counts)
+}
+
+// Bar returns the bar the histogram is displayed in, e.g. to finish it.
+func (h *Histogram) Bar() *Bar {
+	return h.bar
+}
+
+func (h *Histogram) histogram(width int) string {
+	return drawLevels(h.Counts(), width)
+}
+
+// drawLevels draws values as block heights scaled to the largest one, giving each value
+// an equal share of width cells. Adjacent values are merged by maximum when they don't fit.
+func drawLevels(values []int64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return strings.Repeat(" ", max(width, 0))
+	}
+	if len(values) > width {
+		grouped := make([]int64, width)
+		for i, v := range values {
+			g := i * width / len(values)
+			grouped[g] = max(grouped[g], v)
+		}
+		values = grouped
+	}
+	var highest int64
+	for _, v := range values {
+		highest = max(highest, v)
+	}
+	cell := width / len(values)
+	var sb strings.Builder
+	for _, v := range values {
+		level := 0
+		if highest > 0 && v > 0 {
+			level = int((v*8 + highest - 1) / highest) // any nonzero value shows at least the lowest block
+		}
+		sb.WriteString(strings.Repeat(string(levelBlocks[level]), cell))
+	}
+	sb.WriteString(strings.Repeat(" ", width-cell*len(values)))
+	return sb.String()
+}
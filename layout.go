@@ -0,0 +1,181 @@
+package multibar
+
+import (
+	"bytes"
+	"cmp"
+	"io"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Segments are the styled, padded parts of a bar line, exposed to layout templates.
+type Segments struct {
+	Spinner string
+	Desc    string
+	Bar     string
+	Percent string
+	Counter string // "value/max", empty unless enabled with WithCounter or for bytes bars
+	Rate    string // progress per second, empty unless shown
+	Started string // wall-clock start time, empty unless enabled with WithStartTime
+	Elapsed string
+	ETA     string
+	Suffix  string            // current item annotation, see SetSuffix
+	Extra   map[string]string // segments of named decorators, see WithDecorators
+}
+
+// WithLayout controls the order and presence of segments in a line using a text/template
+// executed with Segments, e.g. "{{.Spinner}} {{.Bar}} {{.Percent}} {{.Desc}}".
+// It panics if the layout cannot be parsed.
+func WithLayout(layout string) Option {
+	t := template.Must(template.New("layout").Parse(layout))
+	return func(m *MultiBar) {
+		m.layout = t
+	}
+}
+
+// WithPercentPrecision shows the percent column with 1 or 2 decimals, e.g. " 42.57%",
+// so progress of very large totals stays visible. The column keeps a fixed width.
+func WithPercentPrecision(decimals int) Option {
+	return func(m *MultiBar) {
+		m.percentDecimals = min(max(decimals, 0), 2)
+	}
+}
+
+// WithCounter adds a "value/max" column next to the percentage.
+func WithCounter() Option {
+	return func(m *MultiBar) {
+		m.counter = true
+	}
+}
+
+// defaultStartTimeLayout formats start times, see WithStartTime.
+const defaultStartTimeLayout = "15:04:05"
+
+// WithStartTime adds a column with the wall-clock time each bar started, formatted with the
+// time layout, "15:04:05" when empty, to correlate progress with external events and logs.
+func WithStartTime(layout string) Option {
+	return func(m *MultiBar) {
+		m.startTime = cmp.Or(layout, defaultStartTimeLayout)
+	}
+}
+
+// alignedSegments lists the variable-width segments padded to a common width by alignSegments.
+var alignedSegments = []func(*Segments) *string{
+	func(s *Segments) *string { return &s.Counter },
+	func(s *Segments) *string { return &s.Rate },
+	func(s *Segments) *string { return &s.Started },
+	func(s *Segments) *string { return &s.Elapsed },
+	func(s *Segments) *string { return &s.ETA },
+}
+
+// alignSegments pads variable-width segments to the widest value in the frame.
+func alignSegments(lines []Segments) {
+	for _, field := range alignedSegments {
+		width := 0
+		for i := range lines {
+			width = max(width, visibleWidth(*field(&lines[i])))
+		}
+		for i := range lines {
+			p := field(&lines[i])
+			*p = padLeft(*p, width)
+		}
+	}
+}
+
+func padLeft(s string, width int) string {
+	if pad := width - visibleWidth(s); pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
+
+// visibleWidth returns the number of terminal cells of s, ignoring ANSI escape sequences.
+func visibleWidth(s string) int {
+	return displayWidth(stripStyles(s))
+}
+
+// stripStyles removes ANSI escape sequences from s.
+func stripStyles(s string) string {
+	if !strings.Contains(s, "\033[") {
+		return s
+	}
+	var text strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == '\033' && i+1 < len(s) && s[i+1] == '[' {
+			// Skip CSI sequence up to and including its final byte
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+				i++
+			}
+			i++
+			continue
+		}
+		text.WriteByte(s[i])
+		i++
+	}
+	return text.String()
+}
+
+// truncateStyled cuts s to width visible cells ending with an ellipsis, keeping escape sequences
+// and resetting styles at the end.
+func truncateStyled(s string, width int) string {
+	var out strings.Builder
+	used := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\033' && i+1 < len(s) && s[i+1] == '[' {
+			start := i
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+				i++
+			}
+			i++
+			out.WriteString(s[start:min(i, len(s))])
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := runewidth.RuneWidth(r)
+		if used+w > width-1 {
+			if width > 0 {
+				out.WriteString(ellipsis)
+			}
+			break
+		}
+		out.WriteRune(r)
+		used += w
+		i += size
+	}
+	out.WriteString(colorReset)
+	return out.String()
+}
+
+// displayWidth returns the number of terminal cells of plain text; East Asian wide characters take two.
+func displayWidth(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// writeLine prints the segments using the frame layout, or the decorated segments without one.
+// Empty segments are left out.
+func (s *Segments) writeLine(w io.Writer, f *frame, decorated []string) {
+	if f.layout != nil {
+		var buf bytes.Buffer
+		if err := f.layout.Execute(&buf, s); err == nil {
+			w.Write(buf.Bytes())
+			return
+		}
+	}
+	sep := f.theme.separator()
+	first := true
+	for _, v := range decorated {
+		if v == "" {
+			continue
+		}
+		if !first {
+			io.WriteString(w, sep)
+		}
+		io.WriteString(w, v)
+		first = false
+	}
+}
@@ -0,0 +1,48 @@
+package multibar
+
+import "time"
+
+// WithLazyStart starts the clock of each bar on its first progress update instead of when it is
+// created, so bars created up front for work that begins later don't report inflated elapsed
+// times and bogus ETAs. Until then bars show the StateQueued glyph; (*Bar).Start starts the
+// clock explicitly.
+func WithLazyStart() Option {
+	return func(m *MultiBar) {
+		m.lazyStart = true
+	}
+}
+
+// BarLazyStart starts the clock of the bar on its first progress update, see WithLazyStart.
+func BarLazyStart() BarOption {
+	return func(b *Bar) {
+		b.lazyStart = true
+	}
+}
+
+// Start starts the clock of a bar waiting for its first update, see WithLazyStart.
+// It does nothing if the clock already runs.
+func (b *Bar) Start() {
+	b.mu.Lock()
+	if !b.startedAt.IsZero() || b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	b.startLocked(time.Now())
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// startLocked (re)starts the clock, rates and ETA of the bar at now.
+func (b *Bar) startLocked(now time.Time) {
+	b.startedAt = now
+	b.updatedAt = now
+	b.pausedAt, b.pausedFor = time.Time{}, 0
+	b.longETAAt = time.Time{}
+	b.rates.reset(now, b.value)
+	if b.newEstimator != nil {
+		b.estimator = b.newEstimator()
+	} else if r, ok := b.estimator.(restarter); ok {
+		r.restart()
+	}
+	b.estimator.ObserveProgress(now, b.value, b.max)
+}
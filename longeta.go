@@ -0,0 +1,32 @@
+package multibar
+
+import "time"
+
+const (
+	defaultLongETA = 48 * time.Hour
+	longETARefresh = time.Minute // how long a long estimate is reused
+)
+
+// WithLongETA sets the estimate above which a bar switches its time columns to days and hours,
+// e.g. 3d07h, and re-estimates only once a minute. This keeps absurdly long estimates of very
+// slow bars readable and cheap. Default is 48h; 0 disables the switch.
+func WithLongETA(threshold time.Duration) Option {
+	return func(m *MultiBar) {
+		m.longETA = threshold
+	}
+}
+
+// remainingLocked returns the estimated time left and whether the estimate exceeds threshold.
+// Long estimates are reused for longETARefresh.
+func (b *Bar) remainingLocked(now time.Time, threshold time.Duration) (remaining time.Duration, ok, long bool) {
+	if threshold > 0 && !b.longETAAt.IsZero() && now.Sub(b.longETAAt) < longETARefresh {
+		return b.longETA, true, true
+	}
+	remaining, ok = b.estimator.Remaining(b.activeLocked(now))
+	if threshold > 0 && ok && remaining > threshold {
+		b.longETA, b.longETAAt = remaining, now
+		return remaining, true, true
+	}
+	b.longETAAt = time.Time{}
+	return remaining, ok, false
+}
@@ -0,0 +1,52 @@
+package multibar
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	marqueeStep  = 300 * time.Millisecond // time per scrolled cell
+	marqueePause = 8                      // steps the start of the text stays still
+	marqueeGap   = "   "                  // between the end of the text and its repeated start
+)
+
+// WithMarquee scrolls descriptions of running bars that don't fit the label column
+// horizontally instead of truncating them, so long paths are eventually readable.
+// The text moves as the display is redrawn; finished bars are truncated as usual.
+func WithMarquee() Option {
+	return func(m *MultiBar) {
+		m.marquee = true
+	}
+}
+
+// marqueeLabel returns the window of width cells into s scrolled to the time now.
+func marqueeLabel(s string, width int, now time.Time) string {
+	loop := s + marqueeGap
+	cycle := displayWidth(loop)
+	step := int(now.UnixMilli()/marqueeStep.Milliseconds()) % (cycle + marqueePause)
+	offset := max(step-marqueePause, 0)
+
+	var out strings.Builder
+	pos, used := 0, 0
+	for _, r := range loop + s {
+		w := runewidth.RuneWidth(r)
+		switch {
+		case pos+w <= offset:
+		case pos < offset:
+			// Wide rune cut by the left edge
+			out.WriteString(strings.Repeat(" ", pos+w-offset))
+			used += pos + w - offset
+		case used+w > width:
+			out.WriteString(strings.Repeat(" ", width-used))
+			return out.String()
+		default:
+			out.WriteRune(r)
+			used += w
+		}
+		pos += w
+	}
+	return out.String()
+}
@@ -0,0 +1,52 @@
+package multibar
+
+// UpdateKind tells which call produced an Update.
+type UpdateKind int
+
+const (
+	UpdateAdd UpdateKind = iota // Add: Value is the increment
+	UpdateSet                   // SetValue: Value is the new value
+)
+
+// Update is a value change on its way to the bar, see (*Bar).Use.
+type Update struct {
+	Bar   *Bar
+	Kind  UpdateKind
+	Value int64
+}
+
+// UpdateFunc applies an update.
+type UpdateFunc func(u Update)
+
+// UpdateMiddleware wraps the application of updates. It may observe an update, change it,
+// delay it or drop it by not calling next, e.g. for rate limiting, logging or unit conversion.
+type UpdateMiddleware func(next UpdateFunc) UpdateFunc
+
+// Use adds middlewares around Add and SetValue. The first middleware ever added sees updates
+// first, the last one passes them to the bar.
+func (b *Bar) Use(mw ...UpdateMiddleware) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.middleware = append(b.middleware, mw...)
+	b.chain = applyUpdate
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		b.chain = b.middleware[i](b.chain)
+	}
+}
+
+// updateChain returns the middleware chain, nil without middlewares.
+func (b *Bar) updateChain() UpdateFunc {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.chain
+}
+
+// applyUpdate ends the middleware chain by changing the bar.
+func applyUpdate(u Update) {
+	switch u.Kind {
+	case UpdateAdd:
+		u.Bar.add(u.Value)
+	case UpdateSet:
+		u.Bar.setValue(u.Value)
+	}
+}
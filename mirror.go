@@ -0,0 +1,18 @@
+package multibar
+
+import "io"
+
+// mirror is an extra output receiving plain progress lines, see WithMirror.
+type mirror struct {
+	out *outputQueue
+	log plainLog
+}
+
+// WithMirror also writes progress to w as plain "label: 45% (450/1000)" lines without escape
+// sequences, e.g. to a log file next to the terminal display. Lines come at the plain interval
+// (10 seconds, see WithCIOutput) and when bars finish; the summary report is included.
+func WithMirror(w io.Writer) Option {
+	return func(m *MultiBar) {
+		m.mirrors = append(m.mirrors, &mirror{out: newOutputQueue(w, DropKeepLatest)})
+	}
+}
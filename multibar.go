@@ -1,12 +1,14 @@
 package multibar
 
 import (
-	"fmt"
+	"bytes"
+	"cmp"
+	"context"
 	"io"
-	"os"
+	"slices"
 	"sync"
+	"text/template"
 	"time"
-	"unicode/utf8"
 )
 
 const Undefined = -1
@@ -24,10 +26,15 @@ const (
 	upN          = "\033[%dA"
 	cursorOff    = "\033[?25l"
 	cursorOn     = "\033[?25h"
+	clearLine    = "\033[K"
+	clearDown    = "\033[J"
+	altScreenOn  = "\033[?1049h"
+	altScreenOff = "\033[?1049l"
 )
 
 type Option func(*MultiBar)
 
+// WithWriter sets the output. Defaults to stdout, or stderr when stdout is not a terminal.
 func WithWriter(w io.Writer) Option {
 	return func(m *MultiBar) {
 		m.writer = w
@@ -36,50 +43,174 @@ func WithWriter(w io.Writer) Option {
 
 func New(opts ...Option) *MultiBar {
 	m := &MultiBar{
-		writer: os.Stdout,
+		writer:        defaultWriter(),
+		theme:         DefaultTheme(),
+		spinner:       SpinnerDots,
+		etaMode:       ETATotal,
+		durationFmt:   FormatClock,
+		longETA:       defaultLongETA,
+		newEstimator:  NewLinearEstimator,
+		plainInterval: defaultPlainInterval,
 	}
 	for _, opt := range opts {
 		opt(m)
 	}
+	m.plain = m.ciOutput || plainOutput(m.writer)
+	m.out = newOutputQueue(m.writer, m.dropPolicy)
+	m.out.altScreen = m.altScreen && !m.plain
+	m.connectForward()
+	if m.signals {
+		m.handleSignals()
+	}
+	if m.ctx != nil {
+		context.AfterFunc(m.ctx, m.abort)
+	}
 	return m
 }
 
 type MultiBar struct {
-	bars           []*Bar
-	spinnerIndex   int
-	lastRender     time.Time
-	spinnerUpdate  time.Time
-	maxLabelLength int
-	renderedLines  int
-	writer         io.Writer
-	mu             sync.Mutex
-	renderMu       sync.Mutex
+	bars            []*Bar
+	spinnerIndex    int
+	lastRender      time.Time
+	spinnerUpdate   time.Time
+	maxLabelLength  int
+	title           string
+	footer          bool
+	legend          bool // explain state glyphs, see WithStateLegend
+	report          bool // summary table on Stop, see WithSummaryReport
+	clearOnFinish   bool
+	altScreen       bool
+	hooks           map[Outcome][]func() // see WithCompletionHook
+	writer          io.Writer
+	out             *outputQueue
+	dropPolicy      DropPolicy
+	theme           Theme
+	visible         func(BarSnapshot) bool
+	templates       map[string][]BarOption
+	planned         []plannedBar
+	filter          string
+	sortOrder       SortOrder
+	removeFinished  bool
+	paused          bool
+	disabled        bool // nothing is drawn, see WithDisabled
+	stopping        bool // drawing the final frame
+	stopped         bool
+	plain           bool // no terminal, print plain lines, see writePlain
+	ciOutput        bool
+	plainInterval   time.Duration
+	plainLog        plainLog
+	mirrors         []*mirror
+	strict          bool
+	onMisuse        func(error)
+	forwarding      bool // events go to the parent process, nothing is drawn
+	forwardConn     io.Closer
+	headless        bool // events only, nothing is drawn, see WithJSONOutput
+	layout          *template.Template
+	decorators      []Decorator
+	coldRefresh     time.Duration
+	generation      int // bumped when the frame options change at runtime
+	cpuBudget       float64
+	renderCost      time.Duration // average time spent in render
+	renderInterval  time.Duration // minimal interval between unforced frames, see WithCPUBudget
+	debug           *frameStats   // nil unless WithDebugOverlay
+	counter         bool
+	startTime       string // layout of the start time column, empty when hidden
+	rate            bool
+	rateModes       []RateMode
+	percentDecimals int
+	etaMode         ETAMode
+	rateWindowSize  time.Duration
+	newEstimator    func() Estimator
+	durationFmt     DurationFormatter
+	signals         bool // see WithSignalHandler
+	lazyStart       bool
+	overflow        OverflowPolicy
+	waitMu          sync.Mutex
+	waitCh          chan struct{} // closed on bar changes, see Wait
+	onAllFinished   []func()
+	allFinished     bool          // OnAllFinished callbacks ran since the last bar was created
+	signalDone      chan struct{} // closed by Stop to remove the signal handler
+	longETA         time.Duration // see WithLongETA
+	hidden          Column
+	elidePaths      bool
+	maxLabelWidth   int
+	marquee         bool
+	width           int // forced line width, 0 to detect
+	height          int // forced frame height, 0 to detect
+	heightFit       HeightFit
+	termWidth       int
+	termHeight      int
+	termSizeAt      time.Time
+	stallTimeout    time.Duration
+	onStall         func(*Bar)
+	sinks           []EventSink
+	publishers      []subjectPublisher
+	viewers         map[*viewer]struct{}
+	lastBody        []byte
+	lastPublish     time.Time
+	nextID          int
+	spinner         Spinner
+	mu              sync.Mutex
+	renderMu        sync.Mutex
+	stopOnce        sync.Once
+	tickerDone      chan struct{} // closed by Stop, see startTicker
+	tickerExited    chan struct{}
+	ctx             context.Context // see WithContext
+	contextStop     bool            // stopped by a context of the program, see StopOnContext
 }
 
-func (m *MultiBar) NewBar(maxValue int, description string) *Bar {
-	return m.NewBar64(int64(maxValue), description)
+func (m *MultiBar) NewBar(maxValue int, description string, opts ...BarOption) *Bar {
+	return m.NewBar64(int64(maxValue), description, opts...)
 }
 
-func (m *MultiBar) NewBar64(maxValue int64, description string) *Bar {
+func (m *MultiBar) NewBar64(maxValue int64, description string, opts ...BarOption) *Bar {
 	b := &Bar{
 		mb:          m,
 		max:         maxValue,
 		description: description,
 		startedAt:   time.Now(),
 	}
+	b.rates.window = m.rateWindow()
+	b.rates.reset(b.startedAt, 0)
+	m.mu.Lock()
+	b.newEstimator = m.newEstimator
+	b.stallTimeout = m.stallTimeout
+	b.lazyStart = m.lazyStart
+	b.overflow = m.overflow
+	m.mu.Unlock()
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.lazyStart {
+		b.startedAt = time.Time{}
+	}
+	b.estimator = b.newEstimator()
+	b.estimator.ObserveProgress(b.startedAt, 0, maxValue)
+	fillTable(barWidth) // built once, before the first frame
 	m.mu.Lock()
+	stopped := m.stopped
+	b.stopped = stopped
+	m.nextID++
+	b.id = m.nextID
 	m.bars = append(m.bars, b)
+	m.allFinished = false
+	m.consumePlanLocked(b.description)
 	m.mu.Unlock()
 
+	if stopped {
+		m.misuse(ErrNewBarAfterStop, b.description)
+	}
+
 	// Update max label length for alignment
-	m.updateMaxLabelLength(description)
+	m.updateMaxLabelLength(b.description)
+	m.emit(EventCreated, b.Snapshot())
 
 	return b
 }
 
 // updateMaxLabelLength recalculates the maximum label length for proper alignment
 func (m *MultiBar) updateMaxLabelLength(description string) {
-	descLength := utf8.RuneCountInString(description)
+	descLength := displayWidth(description)
 	m.mu.Lock()
 	if descLength > m.maxLabelLength {
 		m.maxLabelLength = descLength
@@ -87,9 +218,33 @@ func (m *MultiBar) updateMaxLabelLength(description string) {
 	m.mu.Unlock()
 }
 
-// Start should be called after creating all bars to initialize rendering
+// remove deletes the bar and shrinks the label column to the remaining bars.
+func (m *MultiBar) remove(b *Bar) {
+	m.mu.Lock()
+	i := slices.Index(m.bars, b)
+	if i < 0 {
+		m.mu.Unlock()
+		return
+	}
+	m.bars = slices.Delete(m.bars, i, i+1)
+	m.maxLabelLength = 0
+	for _, other := range m.bars {
+		other.mu.Lock()
+		m.maxLabelLength = max(m.maxLabelLength, displayWidth(other.description))
+		other.mu.Unlock()
+	}
+	m.mu.Unlock()
+	m.emit(EventRemoved, b.Snapshot())
+	m.wake()
+	m.render(true)
+}
+
+// Start should be called after creating all bars to initialize rendering. It also starts
+// redrawing at the spinner interval, so spinners and elapsed times move without updates.
+// Stop is required afterwards, e.g. deferred: it ends the redraw and restores the cursor.
 func (m *MultiBar) Start() {
 	m.render()
+	m.startTicker()
 }
 
 /*
@@ -113,6 +268,31 @@ const (
 	barRenderInterval     = 10 * time.Millisecond
 )
 
+// frame holds the state shared by all bars during a single render.
+type frame struct {
+	spinnerIndex    int
+	spinner         Spinner
+	maxLabelLength  int
+	theme           *Theme
+	layout          *template.Template
+	decorators      []Decorator
+	coldRefresh     time.Duration
+	generation      int // bumped when the frame options change at runtime
+	counter         bool
+	startTime       string // layout of the start time column, empty when hidden
+	rate            bool
+	rateModes       []RateMode
+	percentDecimals int
+	etaMode         ETAMode
+	formatDuration  DurationFormatter
+	longETA         time.Duration
+	hidden          Column
+	elidePaths      bool
+	pathSeparator   string
+	maxLabelWidth   int
+	marquee         bool
+}
+
 func (m *MultiBar) render(force ...bool) {
 	// Serialize whole render to avoid interleaved output
 	m.renderMu.Lock()
@@ -120,33 +300,169 @@ func (m *MultiBar) render(force ...bool) {
 
 	m.mu.Lock()
 	now := time.Now()
-	if len(force) > 0 && !force[0] && !m.lastRender.IsZero() && now.Sub(m.lastRender) < barRenderInterval {
+	if m.stopped {
+		m.mu.Unlock()
+		return
+	}
+	// Only drawing is gated, mirrors, viewers, stall callbacks and publishers still get frames
+	draw := !m.paused && !m.disabled && !m.forwarding && !m.headless
+	minInterval := time.Duration(0)
+	if len(force) > 0 && !force[0] {
+		minInterval = barRenderInterval
+	}
+	if len(force) == 0 || !force[0] {
+		minInterval = max(minInterval, m.renderInterval)
+	}
+	if minInterval > 0 && !m.lastRender.IsZero() && now.Sub(m.lastRender) < minInterval {
+		if m.debug != nil {
+			m.debug.dropped++
+		}
 		m.mu.Unlock()
 		return
 	}
 	if m.spinnerUpdate.IsZero() || now.Sub(m.spinnerUpdate) >= spinnerRenderInterval {
-		m.spinnerIndex = (m.spinnerIndex + 1) % len(spinners)
+		m.spinnerIndex++
 		m.spinnerUpdate = now
 	}
 	m.lastRender = now
-	moveUp := m.renderedLines > 0
-	upLines := m.renderedLines
-	writer := m.writer
-	spinnerChar := spinners[m.spinnerIndex]
-	maxLabel := m.maxLabelLength
-	barsCopy := make([]*Bar, len(m.bars))
-	copy(barsCopy, m.bars)
-	m.renderedLines = len(barsCopy)
+	plain, stopping := m.plain, m.stopping
+	var debugLine string
+	if m.debug != nil {
+		debugLine = m.theme.Status.Render(m.debug.line(m.out.droppedFrames()))
+	}
+	m.mu.Unlock()
+
+	lines, allBars := m.compose(now, debugLine)
+	body := frameBuffers.Get().(*bytes.Buffer)
+	body.Reset()
+	defer frameBuffers.Put(body)
+	for _, line := range lines {
+		body.WriteString(line)
+		body.WriteString(clearLine)
+		body.WriteByte('\n')
+	}
+	// Erase lines left over from a taller previous frame
+	body.WriteString(clearDown)
+
+	var seq uint64
+	switch {
+	case !draw:
+	case plain:
+		// Attached viewers still get the full frame. Plain lines cover every bar, so bars
+		// removed on finish still print their final line
+		if text := m.plainText(&m.plainLog, allBars, now, stopping); text != "" {
+			seq = m.out.enqueue(outFrame{body: []byte(text), plain: true})
+		}
+	default:
+		// The queue keeps the frame after the buffer goes back to the pool
+		seq = m.out.enqueue(outFrame{body: bytes.Clone(body.Bytes()), lines: len(lines)})
+	}
+	for _, mr := range m.mirrors {
+		if text := m.plainText(&mr.log, allBars, now, stopping); text != "" {
+			mr.out.enqueue(outFrame{body: []byte(text), plain: true})
+		}
+	}
+	cost := time.Since(now)
+	m.adaptRenderInterval(cost)
+	m.mu.Lock()
+	if m.debug != nil {
+		m.debug.frameDone(now, cost, body.Len())
+	}
 	m.mu.Unlock()
 
-	fmt.Fprint(m.writer, cursorOff)
-	if moveUp {
-		fmt.Fprintf(writer, upN, upLines)
+	m.broadcast(body.Bytes())
+	m.notifyStalls(allBars, now)
+	m.publish(now)
+	if seq > 0 && len(force) > 0 && force[0] {
+		m.out.wait(seq, flushTimeout)
+	}
+}
+
+// frameLocked captures the options shared by all bars of a frame.
+func (m *MultiBar) frameLocked() *frame {
+	theme := m.theme
+	f := &frame{
+		spinnerIndex:    m.spinnerIndex,
+		spinner:         m.spinner,
+		maxLabelLength:  m.maxLabelLength,
+		theme:           &theme,
+		layout:          m.layout,
+		decorators:      m.decorators,
+		coldRefresh:     m.coldRefresh,
+		generation:      m.generation,
+		counter:         m.counter,
+		startTime:       m.startTime,
+		rate:            m.rate,
+		rateModes:       m.rateModes,
+		percentDecimals: m.percentDecimals,
+		etaMode:         m.etaMode,
+		formatDuration:  m.durationFmt,
+		longETA:         m.longETA,
+		hidden:          m.hidden,
+		elidePaths:      m.elidePaths,
+		pathSeparator:   cmp.Or(theme.PathSeparator, defaultPathSeparator),
+		maxLabelWidth:   m.maxLabelWidth,
+		marquee:         m.marquee,
 	}
+	if f.maxLabelWidth > 0 {
+		f.maxLabelLength = min(f.maxLabelLength, f.maxLabelWidth)
+	}
+	return f
+}
+
+// compose lays out the lines of a frame: title, bars with their extra lines fitted to the
+// terminal, then legend, footer and debug lines. It also returns all bars, drawn or not.
+func (m *MultiBar) compose(now time.Time, debugLine string) (lines []string, all []*Bar) {
+	m.mu.Lock()
+	f := m.frameLocked()
+	theme := f.theme
+	all = slices.Clone(m.bars)
+	visible, filter, sortOrder := m.visible, m.filter, m.sortOrder
+	removeFinished, heightFit := m.removeFinished, m.heightFit
+	title, footer, legend := m.title, m.footer, m.legend
+	m.mu.Unlock()
+
+	refreshDerived(all, now)
+	shown := dropFinished(all, removeFinished)
+	shown = arrangeBars(shown, visible, filter, sortOrder)
 
-	for _, bar := range barsCopy {
-		bar.render(writer, spinnerChar, maxLabel)
-		fmt.Fprintln(writer)
+	lines, widest := renderLines(shown, f, now)
+	// Shrink the label column so lines don't wrap, the last cell is left free
+	width, height := m.terminalSize(now)
+	if width > 0 {
+		if overflow := widest - (width - 1); overflow > 0 && f.maxLabelLength > 1 {
+			f.maxLabelLength = max(f.maxLabelLength-overflow, 1)
+			lines, _ = renderLines(shown, f, now)
+		}
+		truncateLines(lines, width-1)
+	}
+	var header, trailer []string
+	if title != "" {
+		header = append(header, titleLine(title, theme, width))
+	}
+	if legend {
+		if line := legendLine(shown, theme, width); line != "" {
+			trailer = append(trailer, line)
+		}
+	}
+	if footer {
+		trailer = append(trailer, footerLine(m.summarize(now), f, now, width))
+	}
+	if debugLine != "" {
+		trailer = append(trailer, debugLine)
+	}
+	blocks := barBlocks(shown, lines, f, width)
+	if height > 0 && heightFit != FitNone {
+		// The cursor ends on the row below the frame, so the frame gets one row less
+		var more int
+		blocks, more = fitHeight(shown, blocks, height-1-len(header)-len(trailer), heightFit, now)
+		if more > 0 {
+			trailer = append([]string{moreLine(more, f)}, trailer...)
+		}
+	}
+	lines = header
+	for _, block := range blocks {
+		lines = append(lines, block...)
 	}
-	fmt.Fprint(m.writer, cursorOn)
+	return append(lines, trailer...), all
 }
@@ -0,0 +1,84 @@
+// Package multibartest helps testing the progress instrumentation of applications:
+// it asserts that bars eventually reach the expected states.
+//
+//	mb := multibartest.New(t)
+//	bar := mb.NewBar(100, "upload")
+//	go upload(bar)
+//	multibartest.Eventually(t, bar).Reaches(50)
+//	multibartest.Eventually(t, bar).Finishes(time.Second)
+package multibartest
+
+import (
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/metalim/multibar"
+)
+
+// DefaultTimeout is how long assertions wait unless Within sets another timeout.
+var DefaultTimeout = 5 * time.Second
+
+// pollInterval is how often the bar state is checked.
+const pollInterval = 5 * time.Millisecond
+
+// New returns a MultiBar that draws nothing, for use in tests.
+func New(t testing.TB, opts ...multibar.Option) *multibar.MultiBar {
+	t.Helper()
+	return multibar.New(append([]multibar.Option{multibar.WithWriter(io.Discard)}, opts...)...)
+}
+
+// Assertion waits for a bar to reach a state, failing the test on timeout.
+type Assertion struct {
+	t       testing.TB
+	bar     *multibar.Bar
+	timeout time.Duration
+}
+
+// Eventually starts an assertion on the bar.
+func Eventually(t testing.TB, bar *multibar.Bar) *Assertion {
+	return &Assertion{t: t, bar: bar, timeout: DefaultTimeout}
+}
+
+// Within sets how long the assertion waits.
+func (a *Assertion) Within(timeout time.Duration) *Assertion {
+	a.timeout = timeout
+	return a
+}
+
+// Finishes waits up to within for the bar to finish.
+func (a *Assertion) Finishes(within time.Duration) bool {
+	a.t.Helper()
+	return a.Within(within).Satisfies("finishes", func(s multibar.BarSnapshot) bool { return s.Finished })
+}
+
+// Reaches waits for the bar value to be at least value.
+func (a *Assertion) Reaches(value int64) bool {
+	a.t.Helper()
+	return a.Satisfies("reaches "+strconv.FormatInt(value, 10), func(s multibar.BarSnapshot) bool { return s.Value >= value })
+}
+
+// HasDescription waits for the bar to have the description.
+func (a *Assertion) HasDescription(description string) bool {
+	a.t.Helper()
+	return a.Satisfies("has description "+description, func(s multibar.BarSnapshot) bool { return s.Description == description })
+}
+
+// Satisfies waits for the bar state to satisfy cond, described by what in the failure message.
+func (a *Assertion) Satisfies(what string, cond func(multibar.BarSnapshot) bool) bool {
+	a.t.Helper()
+	deadline := time.Now().Add(a.timeout)
+	for {
+		s := a.bar.Snapshot()
+		if cond(s) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			a.t.Errorf("bar %q never %s within %v: value %d/%d, finished %v",
+				s.Description, what, a.timeout, s.Value, s.Max, s.Finished)
+			return false
+		}
+		time.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,39 @@
+package multibar
+
+import "time"
+
+// BarOnFinish registers fn to be called when the bar finishes, see (*Bar).OnFinish.
+func BarOnFinish(fn func(b *Bar, elapsed time.Duration)) BarOption {
+	return func(b *Bar) {
+		b.onFinish = append(b.onFinish, fn)
+	}
+}
+
+// OnFinish registers fn to be called once when the bar finishes, by reaching its max, Finish
+// or Fail, with its elapsed time. fn runs in the goroutine that finished the bar, after the
+// finished state is drawn; Snapshot tells whether the bar failed. A bar that is already
+// finished calls fn right away. Aggregates don't call it.
+func (b *Bar) OnFinish(fn func(b *Bar, elapsed time.Duration)) {
+	b.mu.Lock()
+	finished := b.finished && b.derive == nil
+	if !finished {
+		b.onFinish = append(b.onFinish, fn)
+	}
+	elapsed := b.elapsedLocked(time.Now())
+	b.mu.Unlock()
+	if finished {
+		fn(b, elapsed)
+	}
+}
+
+// runFinishHooks calls the OnFinish callbacks after the bar finished.
+func (b *Bar) runFinishHooks() {
+	b.mu.Lock()
+	hooks := b.onFinish
+	elapsed := b.elapsedLocked(b.updatedAt)
+	b.mu.Unlock()
+	for _, fn := range hooks {
+		fn(b, elapsed)
+	}
+	b.mb.barFinished()
+}
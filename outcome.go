@@ -0,0 +1,82 @@
+package multibar
+
+import "slices"
+
+// Outcome summarizes how the tracked work ended, see WithCompletionHook.
+type Outcome int
+
+const (
+	OutcomeSuccess        Outcome = iota // every bar finished within its max
+	OutcomePartialFailure                // some bars failed or didn't finish
+	OutcomeFailure                       // no bar finished successfully
+)
+
+var outcomeNames = [...]string{"success", "partial failure", "failure"}
+
+func (o Outcome) String() string {
+	if o >= 0 && int(o) < len(outcomeNames) {
+		return outcomeNames[o]
+	}
+	return "unknown"
+}
+
+// WithCompletionHook runs hook on Stop when the work ended with the given outcome, e.g. to
+// chime on success and notify on failure. Hooks run after the final frame, in the order given.
+func WithCompletionHook(outcome Outcome, hook func()) Option {
+	return func(m *MultiBar) {
+		if m.hooks == nil {
+			m.hooks = map[Outcome][]func(){}
+		}
+		m.hooks[outcome] = append(m.hooks[outcome], hook)
+	}
+}
+
+// Outcome reports how the work tracked by the bars ended so far: bars that are not finished,
+// failed with Fail or went over their max, count as failed. Aggregates, gauges, histograms and queues are not counted.
+func (m *MultiBar) Outcome() Outcome {
+	m.mu.Lock()
+	bars := slices.Clone(m.bars)
+	m.mu.Unlock()
+	var succeeded, failed int
+	for _, b := range bars {
+		b.mu.Lock()
+		switch {
+		case !b.workLocked():
+		case b.finished && !b.failed && (b.max == Undefined || b.value <= b.max):
+			succeeded++
+		default:
+			failed++
+		}
+		b.mu.Unlock()
+	}
+	switch {
+	case failed == 0:
+		return OutcomeSuccess
+	case succeeded == 0:
+		return OutcomeFailure
+	default:
+		return OutcomePartialFailure
+	}
+}
+
+// runCompletionHooks calls the hooks registered for the outcome of the work.
+func (m *MultiBar) runCompletionHooks() {
+	m.mu.Lock()
+	hooks := m.hooks
+	m.mu.Unlock()
+	if len(hooks) == 0 {
+		return
+	}
+	for _, hook := range hooks[m.Outcome()] {
+		hook()
+	}
+}
+
+// ExitCode returns 0 when all bars finished successfully and 1 otherwise, for
+// os.Exit(mb.ExitCode()) at the end of a CLI.
+func (m *MultiBar) ExitCode() int {
+	if m.Outcome() == OutcomeSuccess {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,18 @@
+package multibar
+
+import "testing"
+
+func TestOutcomeIgnoresHistogramAndQueue(t *testing.T) {
+	mb := New(WithDisabled(true))
+	defer mb.Stop()
+	mb.NewHistogram("latency", []float64{1, 10}).Observe(3)
+	mb.NewQueue("queue", 2).Enqueue(0)
+	mb.NewBar(2, "work").Add(2)
+
+	if got := mb.Outcome(); got != OutcomeSuccess {
+		t.Errorf("Outcome() = %v, want %v", got, OutcomeSuccess)
+	}
+	if got := mb.ExitCode(); got != 0 {
+		t.Errorf("ExitCode() = %d, want 0", got)
+	}
+}
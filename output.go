@@ -0,0 +1,247 @@
+package multibar
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// maxPendingFrames is how many frames may wait for a slow writer with DropOldest.
+	maxPendingFrames = 8
+	// flushTimeout bounds how long forced frames, like a bar finishing, wait to be written.
+	flushTimeout = 100 * time.Millisecond
+	// stopTimeout bounds how long Stop waits for the final frame to be written.
+	stopTimeout = time.Second
+)
+
+// DropPolicy decides what happens to new frames while the writer is still busy with older ones.
+type DropPolicy int
+
+const (
+	DropKeepLatest DropPolicy = iota // default: only the newest waiting frame is kept, the display never lags
+	DropOldest                       // up to maxPendingFrames frames wait, the oldest is dropped when full; smoother but may lag
+	DropNever                        // every frame is written; updates block while the writer is busy
+)
+
+// WithDropPolicy sets what happens to frames when the output is slower than the updates.
+func WithDropPolicy(p DropPolicy) Option {
+	return func(m *MultiBar) {
+		m.dropPolicy = p
+	}
+}
+
+// outputQueue writes frames to the writer in its own goroutine, so a slow or blocked writer
+// never blocks the callers updating bars. Frames waiting for the writer are dropped in favor
+// of newer ones according to the policy; plain text and log lines are never dropped.
+type outputQueue struct {
+	w        io.Writer
+	policy   DropPolicy
+	mu       sync.Mutex
+	cond     *sync.Cond
+	pending  []outFrame
+	onScreen int    // lines of the last written frame, to move the cursor back over it
+	queued   uint64 // sequence number of the last queued frame
+	written  uint64 // sequence number of the last written or superseded frame
+	dropped  int
+	running  bool
+	closed   bool
+	// altScreen draws frames on the alternate screen, entered with the first frame
+	altScreen, inAltScreen bool
+}
+
+// outFrame is a frame waiting to be written.
+type outFrame struct {
+	body  []byte // frame lines without cursor movement, or plain text
+	lines int
+	plain bool
+	log   bool // text printed above the bars, see (*MultiBar).Println
+	seq   uint64
+}
+
+// kept reports whether the frame is text that must never be dropped.
+func (f *outFrame) kept() bool {
+	return f.plain || f.log
+}
+
+func newOutputQueue(w io.Writer, policy DropPolicy) *outputQueue {
+	q := &outputQueue{w: w, policy: policy}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// enqueue queues a frame and returns its sequence number for wait.
+func (q *outputQueue) enqueue(f outFrame) uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.policy == DropNever {
+		for len(q.pending) > 0 && !q.closed {
+			q.cond.Wait()
+		}
+	}
+	if q.closed {
+		return q.queued
+	}
+	q.queued++
+	f.seq = q.queued
+	if n := len(q.pending); f.kept() && n > 0 && q.pending[n-1].plain == f.plain && q.pending[n-1].log == f.log {
+		// Plain text and log lines accumulate instead of replacing
+		last := &q.pending[n-1]
+		last.body = append(last.body, f.body...)
+		last.seq = f.seq
+	} else {
+		if !f.kept() && q.pendingFrames() >= q.limit() {
+			q.dropOldestFrame()
+		}
+		q.pending = append(q.pending, f)
+	}
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+	q.cond.Broadcast()
+	return f.seq
+}
+
+// limit returns how many frames may wait before the oldest is dropped.
+func (q *outputQueue) limit() int {
+	if q.policy == DropOldest {
+		return maxPendingFrames
+	}
+	return 1
+}
+
+// pendingFrames returns how many bar frames wait for the writer, not counting kept text.
+func (q *outputQueue) pendingFrames() int {
+	n := 0
+	for i := range q.pending {
+		if !q.pending[i].kept() {
+			n++
+		}
+	}
+	return n
+}
+
+// dropOldestFrame removes the oldest waiting bar frame, wherever it sits between kept text.
+func (q *outputQueue) dropOldestFrame() {
+	for i := range q.pending {
+		if !q.pending[i].kept() {
+			q.pending = slices.Delete(q.pending, i, i+1)
+			q.dropped++
+			return
+		}
+	}
+}
+
+// run writes queued frames until the queue is closed and drained.
+func (q *outputQueue) run() {
+	q.mu.Lock()
+	for {
+		for len(q.pending) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if len(q.pending) == 0 {
+			q.running = false
+			q.mu.Unlock()
+			return
+		}
+		f := q.pending[0]
+		q.pending = q.pending[1:]
+		up := q.onScreen
+		if !f.plain {
+			q.onScreen = f.lines
+		}
+		q.mu.Unlock()
+
+		q.write(f, up)
+
+		q.mu.Lock()
+		q.written = f.seq
+		q.cond.Broadcast()
+	}
+}
+
+// write prints a frame, moving the cursor back over the up lines of the previous one.
+func (q *outputQueue) write(f outFrame, up int) {
+	if f.plain {
+		q.w.Write(f.body)
+		return
+	}
+	fmt.Fprint(q.w, cursorOff)
+	if q.altScreen && !q.inAltScreen {
+		fmt.Fprint(q.w, altScreenOn+cursorHome)
+		q.inAltScreen = true
+	}
+	if up > 0 {
+		fmt.Fprintf(q.w, upN, up)
+	}
+	if f.log {
+		// The bars are drawn again below the text by the next frame
+		fmt.Fprint(q.w, clearDown)
+	}
+	q.w.Write(f.body)
+	fmt.Fprint(q.w, cursorOn)
+}
+
+// wait blocks until the frame seq or a newer one is written, or timeout passes.
+func (q *outputQueue) wait(seq uint64, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	timer := time.AfterFunc(timeout, func() {
+		q.mu.Lock()
+		q.cond.Broadcast()
+		q.mu.Unlock()
+	})
+	defer timer.Stop()
+	q.mu.Lock()
+	for q.written < seq && !q.closed && time.Now().Before(deadline) {
+		q.cond.Wait()
+	}
+	q.mu.Unlock()
+}
+
+// clear erases the last frame written. Once the queue is closed it writes directly.
+func (q *outputQueue) clear() {
+	q.mu.Lock()
+	if !q.closed {
+		q.mu.Unlock()
+		q.enqueue(outFrame{log: true})
+		return
+	}
+	up := q.onScreen
+	q.onScreen = 0
+	q.mu.Unlock()
+	if up > 0 {
+		q.write(outFrame{log: true}, up)
+	}
+}
+
+// leaveAltScreen queues the switch back to the normal screen, restoring the scrollback.
+func (q *outputQueue) leaveAltScreen() {
+	if q.altScreen {
+		q.enqueue(outFrame{body: []byte(altScreenOff), log: true})
+	}
+}
+
+// close stops the writer goroutine once the queued frames are written.
+func (q *outputQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+}
+
+// droppedFrames returns how many frames were dropped for a slow writer.
+func (q *outputQueue) droppedFrames() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.dropped
+}
+
+// lastQueued returns the sequence number of the last queued frame.
+func (q *outputQueue) lastQueued() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queued
+}
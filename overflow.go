@@ -0,0 +1,44 @@
+package multibar
+
+// OverflowPolicy selects what happens when the value of a bar exceeds its max.
+type OverflowPolicy int
+
+const (
+	OverflowError  OverflowPolicy = iota // default: the bar turns red and counts as failed
+	OverflowClamp                        // the value stops at max and the bar finishes at 100%
+	OverflowExtend                       // max grows with the value; the bar then finishes only with Finish
+	OverflowMisuse                       // reported as ErrValueAboveMax in strict mode, panicking with a nil onMisuse; OverflowError otherwise
+)
+
+// WithOverflowPolicy sets the overflow policy of all bars, e.g. OverflowExtend for streaming
+// jobs with estimated totals that routinely overshoot. Default is OverflowError.
+func WithOverflowPolicy(p OverflowPolicy) Option {
+	return func(m *MultiBar) {
+		m.overflow = p
+	}
+}
+
+// BarOverflow sets the overflow policy of the bar, see WithOverflowPolicy.
+func BarOverflow(p OverflowPolicy) BarOption {
+	return func(b *Bar) {
+		b.overflow = p
+	}
+}
+
+// overflowLocked applies the overflow policy to a value above max. It reports whether the
+// overflow is misuse.
+func (b *Bar) overflowLocked() bool {
+	if b.max == Undefined || b.value <= b.max || b.gauge || b.derive != nil {
+		return false
+	}
+	switch b.overflow {
+	case OverflowClamp:
+		b.value = b.max
+	case OverflowExtend:
+		b.max = b.value
+		b.extended = true
+	case OverflowMisuse:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package multibar
+
+import "slices"
+
+// childBar is a bar counted by a parent bar, see NewParent.
+type childBar struct {
+	bar    *Bar
+	weight float64 // 0 weighs the child by its max
+}
+
+// NewParent creates a bar aggregating its children, added with (*Bar).AddChild or BarParent,
+// and recomputed every frame. Children weighed by their max, the default, simply add up their
+// values and maxes, e.g. a "Total bytes" bar over per-file bytes bars: pass BarUnit(UnitBytes)
+// to show it in bytes. Once a child has an explicit weight, each child contributes weight ×
+// its fraction done out of weight, and the parent shows weights like a float bar.
+// Children with an Undefined max count only with an explicit weight, and only once finished.
+// The parent finishes when all children are finished; removed children no longer count.
+func (m *MultiBar) NewParent(description string, opts ...BarOption) *Bar {
+	var parent *Bar
+	derive := func() (value, maxValue int64, done bool) {
+		parent.mu.Lock()
+		children := slices.Clone(parent.children)
+		parent.mu.Unlock()
+		weighted := slices.ContainsFunc(children, func(c childBar) bool { return c.weight > 0 })
+		m.mu.Lock()
+		shown := make(map[*Bar]bool, len(m.bars))
+		for _, b := range m.bars {
+			shown[b] = true
+		}
+		m.mu.Unlock()
+		done = true
+		matched := 0
+		for _, c := range children {
+			if !shown[c.bar] {
+				continue
+			}
+			c.bar.mu.Lock()
+			v, total, finished := c.bar.value, c.bar.max, c.bar.finished
+			c.bar.mu.Unlock()
+			if c.weight == 0 && total == Undefined {
+				continue
+			}
+			matched++
+			done = done && finished
+			if !weighted {
+				value += v
+				maxValue += total
+				continue
+			}
+			weight := c.weight
+			if weight == 0 {
+				weight = float64(total)
+			}
+			var fraction float64
+			switch {
+			case finished:
+				fraction = 1
+			case total > 0:
+				fraction = float64(min(max(v, 0), total)) / float64(total)
+			}
+			value += toFixed(weight * fraction)
+			maxValue += toFixed(weight)
+		}
+		if matched == 0 {
+			return 0, Undefined, false
+		}
+		return value, maxValue, done
+	}
+	return m.NewBar64(Undefined, description, append(opts, func(b *Bar) {
+		parent = b
+		b.derive = derive
+	})...)
+}
+
+// AddChild counts child in a bar created with NewParent. weight is the share of the child in
+// the parent, e.g. its expected duration; 0 weighs it by its max.
+func (b *Bar) AddChild(child *Bar, weight float64) {
+	b.mu.Lock()
+	b.children = append(b.children, childBar{child, max(weight, 0)})
+	if weight > 0 {
+		b.unit = UnitFloat
+	}
+	b.mu.Unlock()
+}
+
+// BarParent counts the bar in parent, weighed by its max, see NewParent.
+func BarParent(parent *Bar) BarOption {
+	return func(b *Bar) {
+		parent.AddChild(b, 0)
+	}
+}
@@ -0,0 +1,72 @@
+package multibar
+
+import (
+	"slices"
+	"strings"
+)
+
+// defaultPathSeparator joins path parts when the theme does not set one.
+const defaultPathSeparator = " ▸ "
+
+// BarPath sets a hierarchical description, e.g. BarPath("backend", "api", "migrate users")
+// is shown as "backend ▸ api ▸ migrate users".
+func BarPath(parts ...string) BarOption {
+	return func(b *Bar) {
+		b.path = slices.Clone(parts)
+		b.description = strings.Join(parts, b.mb.pathSeparator())
+	}
+}
+
+// SetPath replaces the description with a hierarchical one, see BarPath.
+func (b *Bar) SetPath(parts ...string) {
+	description := strings.Join(parts, b.mb.pathSeparator())
+	b.mu.Lock()
+	b.path = slices.Clone(parts)
+	b.mu.Unlock()
+	b.setDescription(description)
+}
+
+// WithPathElision blanks the leading path parts a bar shares with the bar above it,
+// so deeply namespaced bars read like a tree.
+func WithPathElision() Option {
+	return func(m *MultiBar) {
+		m.elidePaths = true
+	}
+}
+
+func (m *MultiBar) pathSeparator() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.theme.PathSeparator != "" {
+		return m.theme.PathSeparator
+	}
+	return defaultPathSeparator
+}
+
+// sharedPathParts returns how many leading path parts each bar shares with the previous one.
+func sharedPathParts(bars []*Bar) []int {
+	shared := make([]int, len(bars))
+	var prev []string
+	for i, b := range bars {
+		b.mu.Lock()
+		path := b.path
+		b.mu.Unlock()
+		n := 0
+		// Never elide the last part, it names the bar itself
+		for n < len(path)-1 && n < len(prev) && path[n] == prev[n] {
+			n++
+		}
+		shared[i] = n
+		prev = path
+	}
+	return shared
+}
+
+// elidePath replaces the first n parts of the joined path with spaces.
+func elidePath(path []string, n int, separator string) string {
+	if n <= 0 || n >= len(path) {
+		return strings.Join(path, separator)
+	}
+	prefix := strings.Join(path[:n], separator) + separator
+	return strings.Repeat(" ", displayWidth(prefix)) + strings.Join(path[n:], separator)
+}
@@ -0,0 +1,55 @@
+package multibar
+
+import "time"
+
+// Pause stops the clock of the bar, e.g. while a worker waits on a rate limit or a user
+// confirmation, so the wait doesn't count in its elapsed time, rate and ETA. The bar shows
+// the StatePaused glyph and "paused" with the pause duration in the ETA column.
+// Progress reported while paused is still counted.
+func (b *Bar) Pause() {
+	b.mu.Lock()
+	if b.finished || !b.pausedAt.IsZero() {
+		b.mu.Unlock()
+		return
+	}
+	b.pausedAt = time.Now()
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// Resume restarts the elapsed clock stopped by Pause.
+func (b *Bar) Resume() {
+	b.mu.Lock()
+	if b.pausedAt.IsZero() {
+		b.mu.Unlock()
+		return
+	}
+	b.pausedFor += time.Since(b.pausedAt)
+	b.pausedAt = time.Time{}
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// Paused reports whether the bar is paused.
+func (b *Bar) Paused() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.pausedAt.IsZero()
+}
+
+// activeLocked converts wall clock time t to the clock of the bar, which stands still while
+// the bar is paused. Elapsed time and estimates are measured on it.
+func (b *Bar) activeLocked(t time.Time) time.Time {
+	if !b.pausedAt.IsZero() && t.After(b.pausedAt) {
+		t = b.pausedAt
+	}
+	return t.Add(-b.pausedFor)
+}
+
+// pausedLocked returns the total paused time up to now.
+func (b *Bar) pausedLocked(now time.Time) time.Duration {
+	if b.pausedAt.IsZero() {
+		return b.pausedFor
+	}
+	return b.pausedFor + now.Sub(b.pausedAt)
+}
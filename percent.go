@@ -0,0 +1,16 @@
+package multibar
+
+// SetExternalPercent drives the bar by a percentage from an external system that doesn't
+// report counts, e.g. an API returning 0–100. The bar switches to UnitPercent with a max of
+// 100, so its fill, rate and ETA follow the percent history. p is clamped to 0–100.
+func (b *Bar) SetExternalPercent(p int) {
+	p = min(max(p, 0), 100)
+	b.mu.Lock()
+	switched := b.unit != UnitPercent || b.max != 100
+	b.unit = UnitPercent
+	b.mu.Unlock()
+	if switched {
+		b.SetMax(100)
+	}
+	b.SetValue(int64(p))
+}
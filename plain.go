@@ -0,0 +1,104 @@
+package multibar
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// defaultPlainInterval is how often all bars are printed in plain output.
+const defaultPlainInterval = 10 * time.Second
+
+// WithCIOutput prints plain progress lines without any escape sequences, one per bar every
+// interval, even on a terminal. Suited for Jenkins or GitHub Actions logs.
+// Output that is not a terminal gets the same lines every 10 seconds without this option.
+func WithCIOutput(interval time.Duration) Option {
+	return func(m *MultiBar) {
+		m.ciOutput = true
+		if interval > 0 {
+			m.plainInterval = interval
+		}
+	}
+}
+
+// plainOutput reports whether w is a file that is not a terminal, e.g. stdout piped
+// to a file or captured by CI, where cursor movement would produce garbage.
+func plainOutput(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && !term.IsTerminal(int(f.Fd()))
+}
+
+// defaultWriter returns stdout when it is a terminal and stderr otherwise, so bars
+// never mix into results piped from stdout.
+func defaultWriter() io.Writer {
+	if plainOutput(os.Stdout) {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// plainLog tracks what was printed to a plain output.
+type plainLog struct {
+	last     time.Time
+	reported map[*Bar]bool // bars whose finish was printed
+}
+
+// plainText returns "label: 45% (450/1000)" lines without escape sequences: all bars once per
+// plain interval or when final, otherwise only bars that finished since the last call.
+func (m *MultiBar) plainText(log *plainLog, bars []*Bar, now time.Time, final bool) string {
+	m.mu.Lock()
+	all := final || log.last.IsZero() || now.Sub(log.last) >= m.plainInterval
+	if all {
+		log.last = now
+	}
+	if log.reported == nil {
+		log.reported = make(map[*Bar]bool)
+	}
+	var out strings.Builder
+	for _, b := range bars {
+		s := b.Snapshot()
+		if log.reported[b] {
+			continue
+		}
+		if s.Finished {
+			log.reported[b] = true
+		} else if !all {
+			continue
+		}
+		out.WriteString(plainLine(s))
+		out.WriteByte('\n')
+	}
+	m.mu.Unlock()
+	return out.String()
+}
+
+// plainLine formats a bar as "label: 45% (450/1000)".
+func plainLine(s BarSnapshot) string {
+	counter := formatCounter(s.Value, s.Max, s.Unit)
+	if s.Finished && s.Failed {
+		line := fmt.Sprintf("%s: failed (%s)", s.Description, counter)
+		if s.Max != Undefined {
+			percent := strings.TrimSpace(formatPercent(s.Value, s.Max, false, 0))
+			line = fmt.Sprintf("%s: failed at %s (%s)", s.Description, percent, counter)
+		}
+		if s.Error != "" {
+			line += ": " + s.Error
+		}
+		return line
+	}
+	if s.Max == Undefined {
+		if s.Finished {
+			return fmt.Sprintf("%s: done (%s)", s.Description, counter)
+		}
+		return fmt.Sprintf("%s: %s", s.Description, counter)
+	}
+	percent := strings.TrimSpace(formatPercent(s.Value, s.Max, s.Finished, 0))
+	if s.Aborted {
+		return fmt.Sprintf("%s: aborted at %s (%s)", s.Description, percent, counter)
+	}
+	return fmt.Sprintf("%s: %s (%s)", s.Description, percent, counter)
+}
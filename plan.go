@@ -0,0 +1,129 @@
+package multibar
+
+import (
+	"slices"
+	"time"
+)
+
+type plannedBar struct {
+	max         int64
+	description string
+}
+
+// Plan declares a bar that will be created later with the same description,
+// so overall progress accounts for work that has not started yet.
+// Creating a bar with that description consumes the plan.
+func (m *MultiBar) Plan(n int, description string) {
+	m.Plan64(int64(n), description)
+}
+
+func (m *MultiBar) Plan64(n int64, description string) {
+	m.mu.Lock()
+	m.planned = append(m.planned, plannedBar{n, description})
+	m.mu.Unlock()
+}
+
+// consumePlanLocked removes the first plan matching the description.
+func (m *MultiBar) consumePlanLocked(description string) {
+	i := slices.IndexFunc(m.planned, func(p plannedBar) bool { return p.description == description })
+	if i >= 0 {
+		m.planned = slices.Delete(m.planned, i, i+1)
+	}
+}
+
+// Progress returns overall completion in [0, 1]: the done work of all bars with a defined max
+// divided by their total work plus the planned work. Aggregate bars are not counted twice.
+func (m *MultiBar) Progress() float64 {
+	done, total := m.totals()
+	if total <= 0 {
+		return 0
+	}
+	return float64(done) / float64(total)
+}
+
+// totals sums done and total work over bars with a defined max and planned bars.
+func (m *MultiBar) totals() (done, total int64) {
+	s := m.summarize(time.Now())
+	return s.done, s.total
+}
+
+// summary is the overall state of all bars counted as work, see summarize.
+type summary struct {
+	done, total    int64
+	rate           float64 // combined rate of running bars
+	unit           Unit    // common unit of all bars, UnitNone when mixed
+	mixed          bool    // bars of different units, rate is meaningless
+	finished, bars int     // planned bars count as not finished
+	startedAt      time.Time
+}
+
+// summarize sums the state of bars with a defined max and planned bars.
+// Aggregates and gauges are not counted.
+func (m *MultiBar) summarize(now time.Time) summary {
+	var s summary
+	m.mu.Lock()
+	bars := slices.Clone(m.bars)
+	for _, p := range m.planned {
+		if p.max > 0 {
+			s.total += p.max
+		}
+		s.bars++
+	}
+	m.mu.Unlock()
+
+	units := map[Unit]bool{}
+	for _, b := range bars {
+		b.mu.Lock()
+		if b.derive == nil && !b.gauge && b.max != Undefined {
+			s.total += b.max
+			s.bars++
+			switch {
+			case b.finished:
+				s.done += b.max
+				s.finished++
+			case b.value > 0:
+				s.done += min(b.value, b.max)
+			}
+			if !b.finished {
+				s.rate += b.rateLocked(now)
+			}
+			units[b.unit] = true
+			if !b.startedAt.IsZero() && (s.startedAt.IsZero() || b.startedAt.Before(s.startedAt)) {
+				s.startedAt = b.startedAt
+			}
+		}
+		b.mu.Unlock()
+	}
+	if len(units) == 1 {
+		for u := range units {
+			s.unit = u
+		}
+	}
+	s.mixed = len(units) > 1
+	return s
+}
+
+// eta estimates the time until all work is done: remaining work over the combined rate of
+// running bars, or an extrapolation of the overall progress when units are mixed.
+func (s summary) eta(now time.Time) (time.Duration, bool) {
+	if s.total <= 0 || s.done <= 0 || s.done >= s.total {
+		return 0, false
+	}
+	if !s.mixed && s.rate > 0 {
+		return time.Duration(float64(s.total-s.done) / s.rate * float64(time.Second)), true
+	}
+	if s.startedAt.IsZero() {
+		return 0, false
+	}
+	progress := float64(s.done) / float64(s.total)
+	elapsed := now.Sub(s.startedAt)
+	return time.Duration(float64(elapsed) * (1 - progress) / progress), true
+}
+
+// ETA estimates the time until all bars and planned work are done, from the combined rate of
+// running bars and the remaining work. Aggregates and gauges are not counted.
+// It reports false until there is progress to estimate from.
+func (m *MultiBar) ETA() (time.Duration, bool) {
+	now := time.Now()
+	return m.summarize(now).eta(now)
+}
@@ -0,0 +1,39 @@
+package multibar
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// frameBuffers recycles frame bodies, so programs rendering for hours don't grow a new buffer
+// for every frame.
+var frameBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// labelKey is what a rendered label depends on.
+type labelKey struct {
+	description string
+	width       int
+	style       Style
+}
+
+// renderedLabel is the last label of a bar, reused while its key doesn't change.
+type renderedLabel struct {
+	key labelKey
+	out string
+}
+
+// paddedLabel returns description truncated and padded to width cells in the given style.
+// Labels rarely change, so the previous one is reused instead of being built every frame.
+func (b *Bar) paddedLabel(description string, width int, style Style) string {
+	key := labelKey{description, width, style}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.label != nil && b.label.key == key {
+		return b.label.out
+	}
+	description = truncateLabel(description, width)
+	pad := max(width-displayWidth(description), 0)
+	b.label = &renderedLabel{key, style.Render(description) + strings.Repeat(" ", pad)}
+	return b.label.out
+}
@@ -0,0 +1,58 @@
+package multibar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Println prints a line above the bars, like fmt.Println, and draws the bars again below it.
+func (m *MultiBar) Println(a ...any) {
+	m.print(fmt.Sprintln(a...))
+}
+
+// Printf prints formatted text above the bars, like fmt.Printf, and draws the bars again
+// below it. A missing trailing newline is added.
+func (m *MultiBar) Printf(format string, a ...any) {
+	m.print(fmt.Sprintf(format, a...))
+}
+
+func (m *MultiBar) print(text string) {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	m.renderMu.Lock()
+	m.mu.Lock()
+	stopped, plain := m.stopped, m.plain
+	m.mu.Unlock()
+	if stopped {
+		// The final frame stays, text goes below it
+		io.WriteString(m.writer, text)
+	} else {
+		m.out.enqueue(outFrame{body: []byte(text), plain: plain, log: !plain})
+	}
+	m.renderMu.Unlock()
+	if !stopped {
+		m.render(true)
+	}
+}
+
+// above runs write, which prints to the terminal directly, with the bars cleared from the
+// screen, and draws them again below its output.
+func (m *MultiBar) above(write func()) {
+	m.renderMu.Lock()
+	m.mu.Lock()
+	stopped := m.stopped
+	drawn := !stopped && !m.plain && !m.disabled && !m.forwarding && !m.headless
+	m.mu.Unlock()
+	if drawn {
+		m.out.enqueue(outFrame{log: true})
+	}
+	// Queued frames go out before the text
+	m.out.wait(m.out.lastQueued(), flushTimeout)
+	write()
+	m.renderMu.Unlock()
+	if !stopped {
+		m.render(true)
+	}
+}
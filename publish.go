@@ -0,0 +1,76 @@
+package multibar
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Publisher sends a message to a subject (NATS) or topic (MQTT).
+// *nats.Conn satisfies it directly; other clients can be adapted with PublisherFunc.
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// PublisherFunc adapts a function to the Publisher interface, e.g. for an MQTT client:
+//
+//	multibar.PublisherFunc(func(topic string, data []byte) error {
+//		return client.Publish(topic, 0, false, data).Error()
+//	})
+type PublisherFunc func(subject string, data []byte) error
+
+func (f PublisherFunc) Publish(subject string, data []byte) error {
+	return f(subject, data)
+}
+
+// PublishedFrame is the JSON message sent by WithPublisher.
+type PublishedFrame struct {
+	Time time.Time     `json:"ts"`
+	Bars []BarSnapshot `json:"bars"`
+}
+
+// WithPublisher publishes snapshots of all bars to subject as JSON (see PublishedFrame)
+// at the spinner refresh interval. Publish errors are ignored.
+func WithPublisher(p Publisher, subject string) Option {
+	return func(m *MultiBar) {
+		m.publishers = append(m.publishers, subjectPublisher{p, subject})
+	}
+}
+
+type subjectPublisher struct {
+	Publisher
+	subject string
+}
+
+func (m *MultiBar) Snapshots() []BarSnapshot {
+	m.mu.Lock()
+	bars := make([]*Bar, len(m.bars))
+	copy(bars, m.bars)
+	m.mu.Unlock()
+
+	refreshDerived(bars, time.Now())
+	snaps := make([]BarSnapshot, len(bars))
+	for i, b := range bars {
+		snaps[i] = b.Snapshot()
+	}
+	return snaps
+}
+
+// publish sends the current state to all publishers if the refresh interval has passed.
+func (m *MultiBar) publish(now time.Time) {
+	m.mu.Lock()
+	if len(m.publishers) == 0 || now.Sub(m.lastPublish) < spinnerRenderInterval {
+		m.mu.Unlock()
+		return
+	}
+	m.lastPublish = now
+	publishers := m.publishers
+	m.mu.Unlock()
+
+	data, err := json.Marshal(PublishedFrame{Time: now, Bars: m.Snapshots()})
+	if err != nil {
+		return
+	}
+	for _, p := range publishers {
+		p.Publish(p.subject, data)
+	}
+}
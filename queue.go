@@ -0,0 +1,62 @@
+package multibar
+
+import "sync"
+
+// Queue shows the queue depth of every worker of a pool as a small histogram in the bar column,
+// so imbalance is visible at a glance. Columns are scaled to the deepest queue.
+type Queue struct {
+	bar    *Bar
+	mu     sync.Mutex
+	depths []int64
+}
+
+// NewQueue creates a queue display for the given number of workers.
+// Its value is the total number of queued items.
+func (m *MultiBar) NewQueue(description string, workers int, opts ...BarOption) *Queue {
+	q := &Queue{depths: make([]int64, max(workers, 1))}
+	opts = widgetOptions(func(b *Bar) { b.draw = q.histogram }, opts)
+	q.bar = m.NewBar64(Undefined, description, opts...)
+	return q
+}
+
+// Enqueue records an item queued for the worker.
+func (q *Queue) Enqueue(worker int) {
+	q.add(worker, 1)
+}
+
+// Dequeue records an item taken from the worker's queue, e.g. processed or stolen by another worker.
+func (q *Queue) Dequeue(worker int) {
+	q.add(worker, -1)
+}
+
+// Depths returns the current queue depth of every worker.
+func (q *Queue) Depths() []int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]int64(nil), q.depths...)
+}
+
+// Bar returns the bar the queue is displayed in, e.g. to finish it when the pool is done.
+func (q *Queue) Bar() *Bar {
+	return q.bar
+}
+
+func (q *Queue) add(worker int, n int64) {
+	q.mu.Lock()
+	if worker < 0 || worker >= len(q.depths) {
+		q.mu.Unlock()
+		return
+	}
+	q.depths[worker] = max(q.depths[worker]+n, 0)
+	var total int64
+	for _, d := range q.depths {
+		total += d
+	}
+	q.mu.Unlock()
+	q.bar.SetValue(total)
+}
+
+// histogram draws one column per worker, see drawLevels.
+func (q *Queue) histogram(width int) string {
+	return drawLevels(q.Depths(), width)
+}
@@ -0,0 +1,19 @@
+package multibar
+
+// WithDisabled turns all drawing off when disabled is true, e.g. behind a --quiet flag.
+// Bars keep counting, so the same code runs with and without progress output.
+func WithDisabled(disabled bool) Option {
+	return func(m *MultiBar) {
+		m.disabled = disabled
+	}
+}
+
+// SetEnabled turns drawing on or off at runtime. Enabling draws the current state right away.
+func (m *MultiBar) SetEnabled(enabled bool) {
+	m.mu.Lock()
+	m.disabled = !enabled
+	m.mu.Unlock()
+	if enabled {
+		m.render(true)
+	}
+}
@@ -0,0 +1,174 @@
+package multibar
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// defaultRateWindow is the span of recent progress used to compute the current rate.
+	defaultRateWindow = 5 * time.Second
+	// rateSampleInterval is the minimal distance between recorded samples.
+	rateSampleInterval = 100 * time.Millisecond
+)
+
+type rateSample struct {
+	at    time.Time
+	value int64
+}
+
+// rateTracker keeps recent progress samples of a bar, timed on the clock of the bar,
+// which excludes pauses.
+type rateTracker struct {
+	window  time.Duration
+	samples []rateSample
+}
+
+// observe records the value at the given time, keeping samples sparse and within the window.
+func (r *rateTracker) observe(now time.Time, value int64) {
+	if n := len(r.samples); n > 0 && now.Sub(r.samples[n-1].at) < rateSampleInterval {
+		return
+	}
+	r.samples = append(r.samples, rateSample{now, value})
+	// Keep a single sample older than the window as the baseline
+	drop := 0
+	for drop+1 < len(r.samples) && now.Sub(r.samples[drop+1].at) >= r.window {
+		drop++
+	}
+	if drop > 0 {
+		r.samples = append(r.samples[:0], r.samples[drop:]...)
+	}
+}
+
+// rate returns progress per second between the baseline sample and the current value.
+// Rolled back progress, e.g. Add(-n) on retries, counts as no progress rather than a
+// negative rate.
+func (r *rateTracker) rate(now time.Time, value int64) float64 {
+	if len(r.samples) == 0 {
+		return 0
+	}
+	base := r.samples[0]
+	d := now.Sub(base.at)
+	if d <= 0 {
+		return 0
+	}
+	return max(float64(value-base.value)/d.Seconds(), 0)
+}
+
+func (r *rateTracker) reset(now time.Time, value int64) {
+	r.samples = append(r.samples[:0], rateSample{now, value})
+}
+
+// rateLocked returns the current rate of the bar: recent for running bars, average for finished ones.
+func (b *Bar) rateLocked(now time.Time) float64 {
+	if b.finished {
+		return averageRate(b.value, b.elapsedLocked(now))
+	}
+	return b.rates.rate(b.activeLocked(now), b.value)
+}
+
+// WithRate shows the rate column (items/s, or bytes/s for bytes bars) for all bars.
+func WithRate() Option {
+	return func(m *MultiBar) {
+		m.rate = true
+	}
+}
+
+// rateWindow returns the span used by rate trackers of new bars.
+func (m *MultiBar) rateWindow() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rateWindowSize > 0 {
+		return m.rateWindowSize
+	}
+	return defaultRateWindow
+}
+
+// RateMode selects how the rate column is computed.
+type RateMode int
+
+const (
+	RateWindowed RateMode = iota // average over the rate window (default), see WithETAWindow
+	RateInstant                  // progress since the previous sample
+	RateAverage                  // average over the whole run
+)
+
+var rateModeNames = map[RateMode]string{
+	RateWindowed: "window",
+	RateInstant:  "instant",
+	RateAverage:  "avg",
+}
+
+func (r RateMode) String() string {
+	if name, ok := rateModeNames[r]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseRateMode parses the names returned by RateMode.String.
+func ParseRateMode(name string) (RateMode, bool) {
+	for r, n := range rateModeNames {
+		if n == name {
+			return r, true
+		}
+	}
+	return RateWindowed, false
+}
+
+// WithRateMode selects how the rate column is computed. Several modes are shown side by side,
+// each prefixed with its name, e.g. WithRateMode(RateInstant, RateAverage).
+// Finished bars always show their average rate.
+func WithRateMode(modes ...RateMode) Option {
+	return func(m *MultiBar) {
+		m.rateModes = modes
+	}
+}
+
+// SetRateMode changes the rate modes at runtime, see WithRateMode.
+func (m *MultiBar) SetRateMode(modes ...RateMode) {
+	m.mu.Lock()
+	m.rateModes = modes
+	m.generation++
+	m.mu.Unlock()
+	m.render(true)
+}
+
+// instant returns progress per second since the sample before the latest one,
+// so it decays when progress stops.
+func (r *rateTracker) instant(now time.Time, value int64) float64 {
+	n := len(r.samples)
+	if n == 0 {
+		return 0
+	}
+	base := r.samples[max(n-2, 0)]
+	d := now.Sub(base.at)
+	if d <= 0 {
+		return 0
+	}
+	return max(float64(value-base.value)/d.Seconds(), 0)
+}
+
+// rateColumnLocked formats the rate column for the given modes.
+func (b *Bar) rateColumnLocked(now time.Time, modes []RateMode) string {
+	if b.finished || len(modes) == 0 {
+		return formatRate(b.rateLocked(now), b.unit)
+	}
+	parts := make([]string, len(modes))
+	for i, mode := range modes {
+		var rate float64
+		switch mode {
+		case RateInstant:
+			rate = b.rates.instant(b.activeLocked(now), b.value)
+		case RateAverage:
+			rate = averageRate(b.value, b.elapsedLocked(now))
+		default:
+			rate = b.rates.rate(b.activeLocked(now), b.value)
+		}
+		parts[i] = formatRate(rate, b.unit)
+		if len(modes) > 1 {
+			parts[i] = mode.String() + " " + parts[i]
+		}
+	}
+	return strings.Join(parts, " ")
+}
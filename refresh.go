@@ -0,0 +1,58 @@
+package multibar
+
+import "time"
+
+// WithColdRefresh redraws bars without updates for longer than interval only once per interval,
+// while recently updated bars are redrawn every frame. This saves render time with many idle bars;
+// their spinners and elapsed times advance in steps of interval.
+func WithColdRefresh(interval time.Duration) Option {
+	return func(m *MultiBar) {
+		m.coldRefresh = interval
+	}
+}
+
+// segmentCache holds the last rendered segments of a bar.
+type segmentCache struct {
+	segs    Segments
+	version uint64
+	key     cacheKey
+	at      time.Time
+}
+
+// cacheKey is the frame state the cached segments depend on.
+type cacheKey struct {
+	maxLabelLength int
+	elide          int
+	generation     int
+}
+
+// redraw marks the bar changed and renders the frame.
+func (b *Bar) redraw(force ...bool) {
+	b.mu.Lock()
+	b.version++
+	b.mu.Unlock()
+	b.mb.wake()
+	b.mb.render(force...)
+}
+
+// cachedSegments returns the segments of the bar, reusing the previous ones while the bar is cold,
+// unchanged and they are younger than the cold refresh interval.
+func (b *Bar) cachedSegments(f *frame, elide int, now time.Time) Segments {
+	if f.coldRefresh <= 0 {
+		return b.segments(f, elide)
+	}
+	key := cacheKey{f.maxLabelLength, elide, f.generation}
+	b.mu.Lock()
+	cold := now.Sub(b.updatedAt) >= f.coldRefresh
+	c := b.cache
+	version := b.version
+	b.mu.Unlock()
+	if cold && c != nil && c.version == version && c.key == key && now.Sub(c.at) < f.coldRefresh {
+		return c.segs
+	}
+	segs := b.segments(f, elide)
+	b.mu.Lock()
+	b.cache = &segmentCache{segs, version, key, now}
+	b.mu.Unlock()
+	return segs
+}
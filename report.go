@@ -0,0 +1,114 @@
+package multibar
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WithSummaryReport prints a table after the final frame on Stop: total, start and elapsed time,
+// average rate and result of every bar, so tools don't have to track it themselves.
+// Aggregates and gauges are left out.
+func WithSummaryReport() Option {
+	return func(m *MultiBar) {
+		m.report = true
+	}
+}
+
+// reportText returns the summary report of the bars, "" when there are none.
+func (m *MultiBar) reportText(now time.Time) string {
+	m.mu.Lock()
+	bars := slices.Clone(m.bars)
+	formatDuration := m.durationFmt
+	startTime := cmp.Or(m.startTime, defaultStartTimeLayout)
+	m.mu.Unlock()
+
+	rows := [][]string{{"Bar", "Total", "Started", "Elapsed", "Avg rate", "Result"}}
+	var done, failed, incomplete int
+	var started time.Time
+	for _, b := range bars {
+		b.mu.Lock()
+		if b.derive != nil || b.gauge {
+			b.mu.Unlock()
+			continue
+		}
+		elapsed := b.elapsedLocked(now)
+		rate := 0.0
+		if elapsed > 0 {
+			rate = float64(b.value) / elapsed.Seconds()
+		}
+		var result string
+		switch {
+		case b.failed:
+			result = "failed"
+			if b.err != nil {
+				result += ": " + b.err.Error()
+			}
+			failed++
+		case b.max != Undefined && b.value > b.max:
+			result = "failed"
+			failed++
+		case b.aborted:
+			result = "aborted"
+			if b.max != Undefined {
+				result += " at " + strings.TrimSpace(formatPercent(b.value, b.max, false, 0))
+			}
+			incomplete++
+		case b.finished:
+			result = "done"
+			done++
+		case b.max != Undefined:
+			result = "incomplete " + strings.TrimSpace(formatPercent(b.value, b.max, false, 0))
+			incomplete++
+		default:
+			result = "incomplete"
+			incomplete++
+		}
+		startedStr, elapsedStr := "", "-"
+		if !b.startedAt.IsZero() {
+			startedStr, elapsedStr = b.startedAt.Format(startTime), formatDuration(elapsed)
+			if started.IsZero() || b.startedAt.Before(started) {
+				started = b.startedAt
+			}
+		}
+		rows = append(rows, []string{b.description, formatValue(b.value, b.unit), startedStr, elapsedStr, formatRate(rate, b.unit), result})
+		b.mu.Unlock()
+	}
+	if len(rows) == 1 {
+		return ""
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+	var out strings.Builder
+	out.WriteString("\n")
+	for _, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			if i > 0 {
+				line.WriteString("  ")
+			}
+			pad := strings.Repeat(" ", widths[i]-displayWidth(cell))
+			if i == 0 || i == len(row)-1 {
+				line.WriteString(cell + pad) // text columns align left
+			} else {
+				line.WriteString(pad + cell)
+			}
+		}
+		out.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	// No bar may have started, see WithLazyStart
+	total := "-"
+	if !started.IsZero() {
+		total = formatDuration(now.Sub(started))
+	}
+	fmt.Fprintf(&out, "%d bars: %d done, %d failed, %d incomplete in %s\n",
+		len(rows)-1, done, failed, incomplete, total)
+	return out.String()
+}
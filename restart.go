@@ -0,0 +1,19 @@
+package multibar
+
+// ResetOption changes a bar for its next run, see (*Bar).Reset.
+type ResetOption func(*Bar)
+
+// ResetMax sets the max of the next run, e.g. the size of the next file.
+func ResetMax(max int64) ResetOption {
+	return func(b *Bar) {
+		b.max = max
+	}
+}
+
+// ResetDescription sets the description of the next run, replacing a path set with SetPath.
+func ResetDescription(description string) ResetOption {
+	return func(b *Bar) {
+		b.path = nil
+		b.description = description
+	}
+}
@@ -0,0 +1,69 @@
+package multibar
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// WithSignalHandler stops the MultiBar on SIGINT or SIGTERM, drawing the final frame and
+// restoring the cursor, then restores the default action and raises the signal again so the
+// program ends as it would without the handler. This takes over the signals: handlers of the
+// program are reset. A program with its own shutdown, e.g. signal.NotifyContext, passes its
+// context to WithContext or StopOnContext instead; the signal is then not raised again and the
+// program must exit itself. The handler is removed by Stop.
+func WithSignalHandler() Option {
+	return func(m *MultiBar) {
+		m.signals = true
+	}
+}
+
+// handleSignals installs the handler of WithSignalHandler until Stop.
+func (m *MultiBar) handleSignals() {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	m.signalDone = done
+	for _, sig := range []os.Signal{os.Interrupt, syscall.SIGTERM} {
+		// Keep ignoring what the parent told us to ignore, e.g. SIGINT of background jobs
+		if !signal.Ignored(sig) {
+			signal.Notify(ch, sig)
+		}
+	}
+	go func() {
+		select {
+		case <-done:
+			signal.Stop(ch)
+		case sig := <-ch:
+			m.mu.Lock()
+			// The program got the signal too and shuts down on its own
+			own := m.ctx != nil || m.contextStop
+			m.mu.Unlock()
+			m.Stop()
+			signal.Stop(ch)
+			if !own {
+				raise(sig)
+			}
+		}
+	}()
+}
+
+// stopSignals removes the handler of WithSignalHandler.
+func (m *MultiBar) stopSignals() {
+	if m.signalDone != nil {
+		close(m.signalDone)
+	}
+}
+
+// raise delivers sig to the process with its default action, falling back to the exit code
+// shells use for a process killed by it.
+func raise(sig os.Signal) {
+	signal.Reset(sig)
+	if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+		return
+	}
+	code := 1
+	if s, ok := sig.(syscall.Signal); ok {
+		code = 128 + int(s)
+	}
+	os.Exit(code)
+}
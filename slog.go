@@ -0,0 +1,42 @@
+package multibar
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogHandler wraps a slog.Handler to print records above the bars.
+type slogHandler struct {
+	mb    *MultiBar
+	inner slog.Handler
+}
+
+// NewSlogHandler returns a slog.Handler that clears the bars while inner writes a record and
+// draws them again below it, so structured logging and live bars share the terminal.
+// A nil inner writes text records to the output of the bars.
+func NewSlogHandler(mb *MultiBar, inner slog.Handler) slog.Handler {
+	if inner == nil {
+		inner = slog.NewTextHandler(mb.writer, nil)
+	}
+	return &slogHandler{mb: mb, inner: inner}
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	h.mb.above(func() {
+		err = h.inner.Handle(ctx, r)
+	})
+	return err
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &slogHandler{mb: h.mb, inner: h.inner.WithAttrs(attrs)}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{mb: h.mb, inner: h.inner.WithGroup(name)}
+}
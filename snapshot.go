@@ -0,0 +1,105 @@
+package multibar
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// BarSnapshot is a point-in-time copy of a bar's state.
+type BarSnapshot struct {
+	ID          int           `json:"id"`
+	Description string        `json:"description"`
+	Status      string        `json:"status,omitempty"`
+	Value       int64         `json:"value"`
+	Max         int64         `json:"max"`
+	Unit        Unit          `json:"unit,omitempty"`
+	Tags        []string      `json:"tags,omitempty"`
+	Finished    bool          `json:"finished"`
+	Failed      bool          `json:"failed"`               // value exceeds max or Fail was called
+	Error       string        `json:"error,omitempty"`      // see (*Bar).Fail
+	Aborted     bool          `json:"aborted,omitempty"`    // unfinished when the context was done, see WithContext
+	Paused      bool          `json:"paused,omitempty"`     // see (*Bar).Pause
+	PausedFor   time.Duration `json:"paused_for,omitempty"` // total paused time, not counted in Elapsed
+	StartedAt   time.Time     `json:"started_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	Elapsed     time.Duration `json:"elapsed"`
+	Rate        float64       `json:"rate"` // recent progress per second, average once finished
+}
+
+func (b *Bar) Snapshot() BarSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.snapshotLocked(time.Now())
+}
+
+func (b *Bar) snapshotLocked(now time.Time) BarSnapshot {
+	return BarSnapshot{
+		ID:          b.id,
+		Description: b.description,
+		Status:      b.status,
+		Aborted:     b.aborted,
+		Paused:      !b.pausedAt.IsZero(),
+		PausedFor:   b.pausedLocked(now),
+		Value:       b.value,
+		Max:         b.max,
+		Unit:        b.unit,
+		Tags:        slices.Clone(b.tags),
+		Finished:    b.finished,
+		Failed:      b.failed || b.max != Undefined && b.value > b.max,
+		Error:       errorText(b.err),
+		StartedAt:   b.startedAt,
+		UpdatedAt:   b.updatedAt,
+		Elapsed:     b.elapsedLocked(now),
+		Rate:        b.rateLocked(now),
+	}
+}
+
+// elapsedLocked returns the running time of the bar, frozen once it is finished and while it
+// is paused.
+func (b *Bar) elapsedLocked(now time.Time) time.Duration {
+	if b.startedAt.IsZero() {
+		return 0 // not started yet, see WithLazyStart
+	}
+	if b.finished && !b.updatedAt.IsZero() {
+		now = b.updatedAt
+	}
+	return b.activeLocked(now).Sub(b.startedAt)
+}
+
+// WithVisibilityRule hides bars for which rule returns false. The rule is evaluated on every frame;
+// when given several times, a bar is shown only if all rules allow it.
+func WithVisibilityRule(rule func(BarSnapshot) bool) Option {
+	return func(m *MultiBar) {
+		if prev := m.visible; prev != nil {
+			m.visible = func(s BarSnapshot) bool { return prev(s) && rule(s) }
+			return
+		}
+		m.visible = rule
+	}
+}
+
+// RenderString returns the current frame as it would be drawn, without escape sequences and
+// without writing anything, e.g. for error reports and tests. Lines are cut to the width of
+// the output, see WithWidth.
+func (m *MultiBar) RenderString() string {
+	lines, _ := m.compose(time.Now(), "")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(stripStyles(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// String returns the line of the bar as it would be drawn, without escape sequences.
+func (b *Bar) String() string {
+	return strings.TrimRight(stripStyles(b.mb.barLine(b)), " ")
+}
+
+// barLine renders the line of a single bar with the options of the current frame.
+func (m *MultiBar) barLine(b *Bar) string {
+	m.mu.Lock()
+	f := m.frameLocked()
+	m.mu.Unlock()
+	lines, _ := renderLines([]*Bar{b}, f, time.Now())
+	return lines[0]
+}
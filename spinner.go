@@ -0,0 +1,33 @@
+package multibar
+
+import "strings"
+
+// Spinner is a set of frames cycled while a bar is running.
+type Spinner []string
+
+// Built-in spinner presets.
+var (
+	SpinnerDots   = Spinner{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	SpinnerLine   = Spinner{"-", "\\", "|", "/"}
+	SpinnerArrows = Spinner{"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"}
+	SpinnerMoon   = Spinner{"🌑", "🌒", "🌓", "🌔", "🌕", "🌖", "🌗", "🌘"}
+)
+
+// WithSpinner sets the default spinner for all bars.
+func WithSpinner(s Spinner) Option {
+	return func(m *MultiBar) {
+		if len(s) > 0 {
+			m.spinner = s
+		}
+	}
+}
+
+// frameAt returns the frame for the given spinner tick.
+func (s Spinner) frameAt(index int) string {
+	return s[index%len(s)]
+}
+
+// blank returns a placeholder as wide as the spinner frames.
+func (s Spinner) blank() string {
+	return strings.Repeat(" ", displayWidth(s[0]))
+}
@@ -0,0 +1,48 @@
+package multibar
+
+import "time"
+
+// WithStallTimeout marks bars that received no updates for timeout as stalled:
+// the spinner takes the Theme.Stalled style and the ETA column shows how long the bar has been idle.
+// onStall, if not nil, is called once per stall in its own goroutine.
+func WithStallTimeout(timeout time.Duration, onStall func(*Bar)) Option {
+	return func(m *MultiBar) {
+		m.stallTimeout = timeout
+		m.onStall = onStall
+	}
+}
+
+// stalledLocked returns how long the bar has been idle and whether that counts as stalled.
+func (b *Bar) stalledLocked(now time.Time) (time.Duration, bool) {
+	if b.finished || b.stallTimeout <= 0 || !b.pausedAt.IsZero() || b.startedAt.IsZero() {
+		return 0, false
+	}
+	last := b.updatedAt
+	if last.IsZero() {
+		last = b.startedAt
+	}
+	idle := now.Sub(last)
+	return idle, idle >= b.stallTimeout
+}
+
+// notifyStalls calls onStall for bars that became stalled since the last check.
+func (m *MultiBar) notifyStalls(bars []*Bar, now time.Time) {
+	m.mu.Lock()
+	onStall := m.onStall
+	m.mu.Unlock()
+	if onStall == nil {
+		return
+	}
+	for _, b := range bars {
+		b.mu.Lock()
+		_, stalled := b.stalledLocked(now)
+		notify := stalled && !b.stallNotified
+		if notify {
+			b.stallNotified = true
+		}
+		b.mu.Unlock()
+		if notify {
+			go onStall(b)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package multibar
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// BarState replaces the spinner of an unfinished bar with a status icon, see (*Bar).SetState.
+type BarState int
+
+const (
+	StateRunning   BarState = iota // default: the spinner
+	StatePaused                    // ⏸
+	StateQueued                    // ⧗
+	StateDegraded                  // ⚠
+	StateUploading                 // ✈
+)
+
+var stateNames = [...]string{"running", "paused", "queued", "degraded", "uploading"}
+
+func (s BarState) String() string {
+	if s >= 0 && int(s) < len(stateNames) {
+		return stateNames[s]
+	}
+	return "unknown"
+}
+
+// StateGlyph is how a BarState is drawn in the spinner column. Glyphs should be as wide as
+// the spinner frames to keep the labels aligned.
+type StateGlyph struct {
+	Glyph string
+	Style Style
+}
+
+var defaultStateGlyphs = map[BarState]StateGlyph{
+	StatePaused:    {Glyph: "⏸", Style: SGR(2)},
+	StateQueued:    {Glyph: "⧗", Style: SGR(2)},
+	StateDegraded:  {Glyph: "⚠", Style: SGR(38, 5, 214)}, // amber
+	StateUploading: {Glyph: "✈", Style: colorCyan},
+}
+
+// DefaultStateGlyphs returns the glyphs used for states missing from Theme.States.
+func DefaultStateGlyphs() map[BarState]StateGlyph {
+	return maps.Clone(defaultStateGlyphs)
+}
+
+// WithStateLegend adds a line under the bars explaining the state glyphs currently shown,
+// e.g. "⏸ paused  ⚠ degraded", so shared dashboards stay self-explanatory.
+func WithStateLegend() Option {
+	return func(m *MultiBar) {
+		m.legend = true
+	}
+}
+
+// SetState shows a status icon instead of the spinner until the bar finishes.
+// StateRunning restores the spinner.
+func (b *Bar) SetState(s BarState) {
+	b.mu.Lock()
+	b.state = s
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// State returns the state set with SetState.
+func (b *Bar) State() BarState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// displayStateLocked returns the state drawn in the spinner column: paused bars show
+// StatePaused and bars waiting for their first update StateQueued, see WithLazyStart.
+func (b *Bar) displayStateLocked() BarState {
+	if !b.pausedAt.IsZero() {
+		return StatePaused
+	}
+	if b.startedAt.IsZero() && b.state == StateRunning {
+		return StateQueued
+	}
+	return b.state
+}
+
+// state returns the glyph of the state from the theme, or the default one.
+func (t *Theme) state(s BarState) StateGlyph {
+	if g, ok := t.States[s]; ok {
+		return g
+	}
+	return defaultStateGlyphs[s]
+}
+
+// stateGlyph returns the glyph of the state, padded to the width of the spinner frames.
+func (t *Theme) stateGlyph(s BarState, spinner Spinner) (string, Style) {
+	g := t.state(s)
+	if pad := displayWidth(spinner[0]) - displayWidth(g.Glyph); pad > 0 {
+		g.Glyph += spinner.blank()[:pad]
+	}
+	return g.Glyph, g.Style
+}
+
+// legendLine lists the states of the unfinished bars in state order, truncated to width cells,
+// 0 meaning unlimited. Returns "" when all bars show spinners.
+func legendLine(bars []*Bar, theme *Theme, width int) string {
+	seen := map[BarState]bool{}
+	for _, b := range bars {
+		b.mu.Lock()
+		if s := b.displayStateLocked(); !b.finished && s != StateRunning {
+			seen[s] = true
+		}
+		b.mu.Unlock()
+	}
+	if len(seen) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, s := range slices.Sorted(maps.Keys(seen)) {
+		g := theme.state(s)
+		parts = append(parts, g.Style.Render(g.Glyph)+" "+theme.Status.Render(s.String()))
+	}
+	line := strings.Join(parts, "  ")
+	if width > 0 {
+		line = truncateStyled(line, width-1)
+	}
+	return line
+}
@@ -0,0 +1,129 @@
+package multibar
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// SetStatus shows free-form text on an indented line below the bar, e.g. the current file or
+// the last error. Text longer than the terminal width wraps onto continuation lines.
+// Empty text removes the line.
+func (b *Bar) SetStatus(status string) {
+	b.mu.Lock()
+	b.status = status
+	b.mu.Unlock()
+	b.redraw()
+}
+
+// Status returns the text set with SetStatus.
+func (b *Bar) Status() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.status
+}
+
+// barBlocks groups the line of each bar with its detail and status lines. Extra lines start
+// under the label; details are truncated and statuses wrap at width cells, 0 meaning unlimited.
+func barBlocks(bars []*Bar, lines []string, f *frame, width int) [][]string {
+	blocks := make([][]string, len(bars))
+	for i, b := range bars {
+		blocks[i] = []string{lines[i]}
+		b.mu.Lock()
+		status := b.status
+		spinner := b.spinner
+		detail := b.detail
+		b.mu.Unlock()
+		if len(spinner) == 0 {
+			spinner = f.spinner
+		}
+		indent := f.indent(spinner)
+		if detail != nil {
+			if text := detail(b); text != "" {
+				if width > 0 {
+					text = truncateLabel(text, width-1-displayWidth(indent))
+				}
+				blocks[i] = append(blocks[i], indent+f.theme.Status.Render(text))
+			}
+		}
+		if status == "" {
+			continue
+		}
+		for _, line := range wrapText(status, width-1-displayWidth(indent)) {
+			blocks[i] = append(blocks[i], indent+f.theme.Status.Render(line))
+		}
+	}
+	return blocks
+}
+
+// indent returns the blank prefix aligning extra lines with the label of a bar with the spinner.
+func (f *frame) indent(spinner Spinner) string {
+	if f.hidden&ColumnSpinner != 0 {
+		return ""
+	}
+	return spinner.blank() + strings.Repeat(" ", visibleWidth(f.theme.separator()))
+}
+
+// wrapText breaks s into lines of at most width cells at spaces, splitting words that don't fit.
+// Explicit line breaks are kept. Width below 1 means unlimited.
+func wrapText(s string, width int) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		if width < 1 || displayWidth(para) <= width {
+			lines = append(lines, para)
+			continue
+		}
+		line, lineWidth := "", 0
+		for _, word := range strings.Fields(para) {
+			w := displayWidth(word)
+			if lineWidth > 0 && lineWidth+1+w <= width {
+				line, lineWidth = line+" "+word, lineWidth+1+w
+				continue
+			}
+			if lineWidth > 0 {
+				lines = append(lines, line)
+			}
+			for w > width {
+				head := runewidth.Truncate(word, width, "")
+				if head == "" {
+					// A wide character doesn't fit at all, give it a line of its own
+					_, size := utf8.DecodeRuneInString(word)
+					head = word[:size]
+				}
+				lines = append(lines, head)
+				word = word[len(head):]
+				w = displayWidth(word)
+			}
+			line, lineWidth = word, w
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// SetTitle shows a header line above all bars, e.g. "Deploying release v1.2.3".
+// Empty title removes it.
+func (m *MultiBar) SetTitle(title string) {
+	m.mu.Lock()
+	m.title = title
+	m.mu.Unlock()
+	m.render(true)
+}
+
+// titleLine renders the header truncated to width cells, 0 meaning unlimited.
+func titleLine(title string, theme *Theme, width int) string {
+	if width > 0 {
+		title = truncateLabel(title, width-1)
+	}
+	return theme.Title.Render(title)
+}
+
+// SetSuffix shows a short annotation after the time columns, e.g. "→ photos/IMG_2041.jpg".
+// Unlike the description it doesn't affect the alignment of other bars; too long suffixes are cut.
+func (b *Bar) SetSuffix(suffix string) {
+	b.mu.Lock()
+	b.suffix = suffix
+	b.mu.Unlock()
+	b.redraw()
+}
@@ -0,0 +1,62 @@
+package multibar
+
+import (
+	"slices"
+	"time"
+)
+
+// Stop draws the final frame, or erases it with WithClearOnFinish, and stops drawing,
+// followed by the summary table of WithSummaryReport, then runs the completion hooks.
+// Updates of the bars after Stop are ignored, and bars created after Stop are not shown.
+// Internal goroutines end, attached viewers are released and the signal handler is removed.
+// Later calls wait for the first one to complete.
+func (m *MultiBar) Stop() {
+	m.stopOnce.Do(m.stop)
+}
+
+func (m *MultiBar) stop() {
+	m.stopTicker()
+	m.flushProgressEvents(time.Now(), true)
+	m.mu.Lock()
+	m.stopping = true
+	m.mu.Unlock()
+	m.render(true)
+	m.mu.Lock()
+	m.stopping = false
+	m.stopped = true
+	bars := slices.Clone(m.bars)
+	forward := m.forwardConn
+	report := m.report && !m.disabled && !m.forwarding && !m.headless
+	erase := m.clearOnFinish && !m.plain && !m.disabled && !m.forwarding && !m.headless
+	m.mu.Unlock()
+	for _, b := range bars {
+		b.mu.Lock()
+		b.stopped = true
+		b.mu.Unlock()
+	}
+	if erase {
+		m.out.clear()
+	}
+	m.out.leaveAltScreen()
+	if report {
+		if text := m.reportText(time.Now()); text != "" {
+			m.out.enqueue(outFrame{body: []byte(text), plain: true})
+			for _, mr := range m.mirrors {
+				mr.out.enqueue(outFrame{body: []byte(text), plain: true})
+			}
+		}
+	}
+	m.out.wait(m.out.lastQueued(), stopTimeout)
+	m.out.close()
+	for _, mr := range m.mirrors {
+		mr.out.wait(mr.out.lastQueued(), stopTimeout)
+		mr.out.close()
+	}
+	m.releaseViewers()
+	if forward != nil {
+		forward.Close()
+	}
+	m.stopSignals()
+	m.wake()
+	m.runCompletionHooks()
+}
@@ -0,0 +1,39 @@
+package multibar
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Misuse errors reported in strict mode, see WithStrict.
+var (
+	ErrAddAfterFinish  = errors.New("progress after finish")
+	ErrMaxBelowValue   = errors.New("max set below current value")
+	ErrNewBarAfterStop = errors.New("bar created after stop")
+	ErrValueAboveMax   = errors.New("value above max") // only with OverflowMisuse
+)
+
+// WithStrict reports API misuse to onMisuse: progress on a finished bar, SetMax below the
+// current value, bars created after Stop and values above max of bars with OverflowMisuse.
+// The errors wrap ErrAddAfterFinish, ErrMaxBelowValue, ErrNewBarAfterStop and ErrValueAboveMax. A nil onMisuse panics, for development builds.
+func WithStrict(onMisuse func(error)) Option {
+	return func(m *MultiBar) {
+		m.strict = true
+		m.onMisuse = onMisuse
+	}
+}
+
+// misuse reports err about the bar with the given description in strict mode.
+func (m *MultiBar) misuse(err error, description string) {
+	m.mu.Lock()
+	strict, onMisuse := m.strict, m.onMisuse
+	m.mu.Unlock()
+	if !strict {
+		return
+	}
+	err = fmt.Errorf("multibar: %w: %q", err, description)
+	if onMisuse == nil {
+		panic(err)
+	}
+	onMisuse(err)
+}
@@ -0,0 +1,98 @@
+package multibar
+
+import (
+	"slices"
+	"time"
+)
+
+// Tag adds tags to the bar, e.g. b.Tag("us-east", "upload"). Tags select bars for aggregates.
+func (b *Bar) Tag(tags ...string) {
+	b.mu.Lock()
+	for _, t := range tags {
+		if !slices.Contains(b.tags, t) {
+			b.tags = append(b.tags, t)
+		}
+	}
+	b.mu.Unlock()
+}
+
+// Tags returns the tags of the bar.
+func (b *Bar) Tags() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.tags)
+}
+
+// BarTags tags the bar at creation, see (*Bar).Tag.
+func BarTags(tags ...string) BarOption {
+	return func(b *Bar) {
+		b.tags = slices.Clone(tags)
+	}
+}
+
+// NewAggregate creates a bar showing the total of all bars carrying every one of the tags
+// (all bars if no tags are given). Value and max are sums over the matching bars with a defined max;
+// the aggregate finishes when all of them are finished. Aggregates are recomputed every frame
+// and never include other aggregates or gauges.
+func (m *MultiBar) NewAggregate(description string, tags ...string) *Bar {
+	query := slices.Clone(tags)
+	derive := func() (value, maxValue int64, done bool) {
+		m.mu.Lock()
+		bars := slices.Clone(m.bars)
+		m.mu.Unlock()
+		done = true
+		matched := 0
+		for _, b := range bars {
+			b.mu.Lock()
+			ok := b.derive == nil && !b.gauge && b.max != Undefined && hasAllTags(b.tags, query)
+			if ok {
+				matched++
+				value += b.value
+				maxValue += b.max
+				done = done && b.finished
+			}
+			b.mu.Unlock()
+		}
+		if matched == 0 {
+			return 0, Undefined, false
+		}
+		return value, maxValue, done
+	}
+	return m.NewBar64(Undefined, description, func(b *Bar) { b.derive = derive })
+}
+
+func hasAllTags(tags, query []string) bool {
+	for _, q := range query {
+		if !slices.Contains(tags, q) {
+			return false
+		}
+	}
+	return true
+}
+
+// refreshDerived recomputes the state of aggregate bars.
+func refreshDerived(bars []*Bar, now time.Time) {
+	for _, b := range bars {
+		b.mu.Lock()
+		derive := b.derive
+		b.mu.Unlock()
+		if derive == nil {
+			continue
+		}
+		value, maxValue, done := derive()
+
+		b.mu.Lock()
+		if value != b.value || maxValue != b.max {
+			b.version++
+			b.value, b.max = value, maxValue
+			b.updatedAt = now
+			b.observeLocked(now)
+		}
+		if done != b.finished {
+			b.version++
+			b.finished = done
+			b.updatedAt = now
+		}
+		b.mu.Unlock()
+	}
+}
@@ -0,0 +1,110 @@
+package multibar
+
+import (
+	"cmp"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// ellipsis marks truncated labels.
+const ellipsis = "…"
+
+// WithMaxLabelWidth truncates descriptions longer than width cells with an ellipsis.
+func WithMaxLabelWidth(width int) Option {
+	return func(m *MultiBar) {
+		m.maxLabelWidth = width
+	}
+}
+
+// WithWidth sets the line width to fit into instead of detecting the terminal width.
+// Labels are truncated so lines never wrap. Zero restores detection.
+func WithWidth(width int) Option {
+	return func(m *MultiBar) {
+		m.width = width
+	}
+}
+
+// terminalSize returns the width lines must fit into and the number of rows the frame must fit into,
+// 0 if unknown. The detected size is refreshed at most once per spinner interval.
+func (m *MultiBar) terminalSize(now time.Time) (width, height int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if now.Sub(m.termSizeAt) >= spinnerRenderInterval {
+		m.termWidth, m.termHeight = detectSize(m.writer)
+		m.termSizeAt = now
+	}
+	return cmp.Or(m.width, m.termWidth), cmp.Or(m.height, m.termHeight)
+}
+
+// detectSize returns the size of the terminal behind w, or zeros if w is not a terminal.
+func detectSize(w io.Writer) (width, height int) {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0, 0
+	}
+	width, height, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0, 0
+	}
+	return width, height
+}
+
+// truncateLabel shortens s to width cells, ending it with an ellipsis.
+func truncateLabel(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return runewidth.Truncate(s, width, ellipsis)
+}
+
+// renderLines renders one line per bar, without line endings. It also returns the width of the
+// widest line not counting suffixes, which are truncated instead of shrinking the labels.
+func renderLines(bars []*Bar, f *frame, now time.Time) ([]string, int) {
+	var elide []int
+	if f.elidePaths {
+		elide = sharedPathParts(bars)
+	}
+	segs := make([]Segments, len(bars))
+	for i, bar := range bars {
+		n := 0
+		if elide != nil {
+			n = elide[i]
+		}
+		segs[i] = bar.cachedSegments(f, n, now)
+	}
+	alignSegments(segs)
+	decorators := f.decorators
+	if decorators == nil {
+		decorators = DefaultDecorators()
+	}
+	decorated := decorate(bars, segs, decorators)
+	lines := make([]string, len(segs))
+	widest := 0
+	sepWidth := visibleWidth(f.theme.separator())
+	var sb strings.Builder
+	for i := range segs {
+		sb.Reset()
+		segs[i].writeLine(&sb, f, decorated[i])
+		lines[i] = sb.String()
+		w := visibleWidth(lines[i])
+		if segs[i].Suffix != "" {
+			w -= sepWidth + visibleWidth(segs[i].Suffix)
+		}
+		widest = max(widest, w)
+	}
+	return lines, widest
+}
+
+// truncateLines cuts lines wider than width cells, keeping their styles intact.
+func truncateLines(lines []string, width int) {
+	for i, line := range lines {
+		if visibleWidth(line) > width {
+			lines[i] = truncateStyled(line, width)
+		}
+	}
+}
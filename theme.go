@@ -0,0 +1,132 @@
+package multibar
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Style is an SGR escape sequence applied to a piece of output. Empty style means no styling.
+type Style string
+
+// SGR builds a Style from SGR parameters, e.g. SGR(1, 35) for bold magenta.
+func SGR(params ...int) Style {
+	if len(params) == 0 {
+		return ""
+	}
+	parts := make([]string, len(params))
+	for i, p := range params {
+		parts[i] = strconv.Itoa(p)
+	}
+	return Style("\033[" + strings.Join(parts, ";") + "m")
+}
+
+// Render wraps text in the style, resetting attributes afterwards.
+func (s Style) Render(text string) string {
+	if s == "" || text == "" {
+		return text
+	}
+	return string(s) + text + colorReset
+}
+
+// Theme controls column separators, per-column styles and state colors.
+type Theme struct {
+	Separator      string // printed between columns
+	SeparatorStyle Style
+	PathSeparator  string // joins parts of hierarchical descriptions, " ▸ " when empty
+	Spinner        Style  // spinner of a running bar
+	Label          Style
+	Bar            Style // fill of a running bar
+	BarFinished    Style // fill and spinner of a finished bar
+	BarError       Style // fill and spinner of a bar with value > max
+	Stalled        Style // spinner of a bar without recent updates, see WithStallTimeout
+	Gradient       []RGB // color stops for GradientMode, e.g. GradientTrafficLight
+	GradientMode   GradientMode
+	Percent        Style
+	Counter        Style
+	Rate           Style
+	Started        Style // start time, see WithStartTime
+	Elapsed        Style
+	ETA            Style
+	Suffix         Style                   // current item after the time columns, see (*Bar).SetSuffix
+	Primary        Style                   // emphasis of RolePrimary bars
+	Secondary      Style                   // emphasis of RoleSecondary bars
+	Detail         Style                   // emphasis of RoleDetail bars
+	Status         Style                   // secondary line below a bar, see (*Bar).SetStatus
+	Title          Style                   // header line above the bars, see (*MultiBar).SetTitle
+	States         map[BarState]StateGlyph // icons replacing the spinner, DefaultStateGlyphs for missing ones
+}
+
+// Role selects how much a bar stands out from the others.
+type Role int
+
+const (
+	RoleSecondary Role = iota // default
+	RolePrimary               // headline bars, e.g. totals
+	RoleDetail                // per-item noise
+)
+
+// DefaultTheme returns the theme used when no WithTheme option is given.
+func DefaultTheme() Theme {
+	return Theme{
+		Separator:   " ",
+		BarFinished: colorGreen,
+		BarError:    colorRed,
+		Stalled:     SGR(38, 5, 214), // amber
+		Percent:     colorMagenta,
+		Elapsed:     colorYellow,
+		ETA:         colorCyan,
+		Primary:     SGR(1),
+		Detail:      SGR(2),
+		Status:      SGR(2),
+		Title:       SGR(1),
+	}
+}
+
+// DimTheme returns a low-contrast theme for light terminal backgrounds.
+func DimTheme() Theme {
+	return Theme{
+		Separator:   " ",
+		Spinner:     SGR(2),
+		Label:       SGR(2),
+		Bar:         SGR(2),
+		BarFinished: SGR(2, 32),
+		BarError:    SGR(2, 31),
+		Stalled:     SGR(2, 33),
+		Percent:     SGR(2, 35),
+		Elapsed:     SGR(2, 33),
+		ETA:         SGR(2, 36),
+		Primary:     SGR(1),
+		Status:      SGR(2),
+		Title:       SGR(1),
+	}
+}
+
+// MonochromeTheme returns a theme without any styling.
+func MonochromeTheme() Theme {
+	states := DefaultStateGlyphs()
+	for s, g := range states {
+		states[s] = StateGlyph{Glyph: g.Glyph}
+	}
+	return Theme{Separator: " ", States: states}
+}
+
+func WithTheme(t Theme) Option {
+	return func(m *MultiBar) {
+		m.theme = t
+	}
+}
+
+func (t *Theme) separator() string {
+	return t.SeparatorStyle.Render(t.Separator)
+}
+
+func (t *Theme) emphasis(r Role) Style {
+	switch r {
+	case RolePrimary:
+		return t.Primary
+	case RoleDetail:
+		return t.Detail
+	default:
+		return t.Secondary
+	}
+}
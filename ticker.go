@@ -0,0 +1,40 @@
+package multibar
+
+import "time"
+
+// startTicker redraws at the spinner interval until Stop, so spinners and timers keep moving
+// without progress updates.
+func (m *MultiBar) startTicker() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tickerDone != nil || m.stopping || m.stopped {
+		return
+	}
+	done, exited := make(chan struct{}), make(chan struct{})
+	m.tickerDone, m.tickerExited = done, exited
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(spinnerRenderInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				m.render()
+				m.flushProgressEvents(time.Now(), false)
+			}
+		}
+	}()
+}
+
+// stopTicker stops the ticker goroutine and waits for it to exit.
+func (m *MultiBar) stopTicker() {
+	m.mu.Lock()
+	done, exited := m.tickerDone, m.tickerExited
+	m.mu.Unlock()
+	if done != nil {
+		close(done)
+		<-exited
+	}
+}
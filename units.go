@@ -0,0 +1,71 @@
+package multibar
+
+import (
+	"strconv"
+	"time"
+)
+
+// Unit tells how values of a bar are displayed.
+type Unit string
+
+const (
+	UnitNone    Unit = ""        // plain counts
+	UnitBytes   Unit = "bytes"   // humanized sizes, e.g. 1.4 MiB
+	UnitPercent Unit = "percent" // percent reported by an external system, see (*Bar).SetExternalPercent
+	UnitFloat   Unit = "float"   // fractional values in FloatScale units, see NewBarFloat
+)
+
+// NewBytesBar creates a bar counting bytes. It always shows the humanized value/max and the transfer rate.
+func (m *MultiBar) NewBytesBar(maxValue int64, description string, opts ...BarOption) *Bar {
+	return m.NewBar64(maxValue, description, append([]BarOption{BarUnit(UnitBytes)}, opts...)...)
+}
+
+// formatValue formats a value of the given unit.
+func formatValue(v int64, unit Unit) string {
+	switch unit {
+	case UnitBytes:
+		return formatBytes(v)
+	case UnitPercent:
+		return strconv.FormatInt(v, 10) + "%"
+	case UnitFloat:
+		return formatFloat(float64(v) / FloatScale)
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+// formatBytes formats n using binary prefixes: 512 B, 1.4 MiB, 2.0 GiB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit && n > -unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+	value := float64(n)
+	prefixes := "KMGTPE"
+	i := -1
+	for (value >= unit || value <= -unit) && i < len(prefixes)-1 {
+		value /= unit
+		i++
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + " " + prefixes[i:i+1] + "iB"
+}
+
+// formatRate formats progress per second in the given unit.
+func formatRate(perSecond float64, unit Unit) string {
+	switch unit {
+	case UnitBytes:
+		return formatBytes(int64(perSecond)) + "/s"
+	case UnitPercent:
+		return strconv.FormatFloat(perSecond, 'f', 1, 64) + "%/s"
+	case UnitFloat:
+		return formatFloat(perSecond/FloatScale) + "/s"
+	}
+	return strconv.FormatFloat(perSecond, 'f', 1, 64) + "/s"
+}
+
+// averageRate returns value per second over the elapsed time.
+func averageRate(value int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(value) / elapsed.Seconds()
+}
@@ -0,0 +1,56 @@
+package multibar
+
+import "slices"
+
+// Wait blocks until every bar is complete: bars with a max reach it or are finished,
+// Undefined bars are finished. Aggregates, gauges, histograms and queues are not waited for. Wait also returns
+// once the MultiBar is stopped, e.g. by WithContext. It doesn't stop the MultiBar.
+func (m *MultiBar) Wait() {
+	for {
+		changed := m.changed()
+		if m.complete() {
+			return
+		}
+		<-changed
+	}
+}
+
+// changed returns a channel closed by the next change of a bar.
+func (m *MultiBar) changed() <-chan struct{} {
+	m.waitMu.Lock()
+	defer m.waitMu.Unlock()
+	if m.waitCh == nil {
+		m.waitCh = make(chan struct{})
+	}
+	return m.waitCh
+}
+
+// wake releases the callers of Wait to check the bars again.
+func (m *MultiBar) wake() {
+	m.waitMu.Lock()
+	if m.waitCh != nil {
+		close(m.waitCh)
+		m.waitCh = nil
+	}
+	m.waitMu.Unlock()
+}
+
+// complete reports whether Wait may return.
+func (m *MultiBar) complete() bool {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return true
+	}
+	bars := slices.Clone(m.bars)
+	m.mu.Unlock()
+	for _, b := range bars {
+		b.mu.Lock()
+		done := !b.workLocked() || b.finished || b.max != Undefined && b.value >= b.max
+		b.mu.Unlock()
+		if !done {
+			return false
+		}
+	}
+	return true
+}
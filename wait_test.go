@@ -0,0 +1,26 @@
+package multibar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitIgnoresHistogramAndQueue(t *testing.T) {
+	mb := New(WithDisabled(true))
+	defer mb.Stop()
+	mb.NewHistogram("latency", []float64{1, 10}).Observe(3)
+	mb.NewQueue("queue", 2).Enqueue(0)
+	bar := mb.NewBar(2, "work")
+
+	done := make(chan struct{})
+	go func() {
+		mb.Wait()
+		close(done)
+	}()
+	bar.Add(2)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait still blocked after the work bar finished")
+	}
+}
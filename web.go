@@ -0,0 +1,65 @@
+package multibar
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+//go:embed web/index.html
+var webIndex []byte
+
+// WebHandler serves a live view of the bars: the page at "/", a WebSocket feed of PublishedFrame messages at "/ws"
+// and ControlHandler under "/control/". WebSockets opened by pages of other origins are rejected.
+func (m *MultiBar) WebHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(webIndex)
+	})
+	mux.HandleFunc("/ws", m.serveWebSocket)
+	mux.Handle("/control/", http.StripPrefix("/control", m.ControlHandler()))
+	return mux
+}
+
+// ServeWeb serves WebHandler on addr. It blocks like http.ListenAndServe.
+func (m *MultiBar) ServeWeb(addr string) error {
+	return http.ListenAndServe(addr, m.WebHandler())
+}
+
+// serveWebSocket pushes snapshots of all bars to the client at the spinner refresh interval.
+func (m *MultiBar) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	done := make(chan struct{})
+	go func() {
+		ws.readLoop()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(spinnerRenderInterval)
+	defer ticker.Stop()
+	for {
+		data, err := json.Marshal(PublishedFrame{Time: time.Now(), Bars: m.Snapshots()})
+		if err != nil {
+			return
+		}
+		if err := ws.WriteText(data); err != nil {
+			return
+		}
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
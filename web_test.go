@@ -0,0 +1,24 @@
+package multibar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebSocketRejectsForeignOrigin(t *testing.T) {
+	mb := New(WithDisabled(true))
+	defer mb.Stop()
+	r := httptest.NewRequest(http.MethodGet, "http://localhost:8080/ws", nil)
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	r.Header.Set("Origin", "http://evil.example")
+	w := httptest.NewRecorder()
+
+	mb.WebHandler().ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}